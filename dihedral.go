@@ -77,13 +77,15 @@ func main() {
 	targets := result.Targets
 	providers := result.Providers
 	bindings := result.Bindings
+	subcomponents := result.Subcomponents
 
 	fmt.Printf("Found target interface %s\n", targetInterfaceName)
 	fmt.Printf("Found targets: %+v\n", targets)
 	fmt.Printf("Found providers: %+v\n", providers)
 	fmt.Printf("Found bindings: %+v\n", bindings)
+	fmt.Printf("Found subcomponents: %+v\n", subcomponents)
 
-	component, err := gen.NewGeneratedComponent(targetInterfaceName, targets, providers, bindings)
+	component, err := gen.NewGeneratedComponent(result)
 	if err != nil {
 		panic(err)
 	}
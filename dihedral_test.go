@@ -1,11 +1,54 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/dimes/dihedral/inject"
 	"github.com/dimes/dihedral/internal/example"
+	"github.com/dimes/dihedral/internal/example/assisted"
+	assisteddigen "github.com/dimes/dihedral/internal/example/assisted/digen"
+	"github.com/dimes/dihedral/internal/example/background"
+	backgrounddigen "github.com/dimes/dihedral/internal/example/background/digen"
+	"github.com/dimes/dihedral/internal/example/bindings"
 	"github.com/dimes/dihedral/internal/example/bindings/digen"
+	"github.com/dimes/dihedral/internal/example/commands"
+	commandsdigen "github.com/dimes/dihedral/internal/example/commands/digen"
+	"github.com/dimes/dihedral/internal/example/concurrency"
+	concurrencydigen "github.com/dimes/dihedral/internal/example/concurrency/digen"
+	"github.com/dimes/dihedral/internal/example/constructors"
+	constructorsdigen "github.com/dimes/dihedral/internal/example/constructors/digen"
+	"github.com/dimes/dihedral/internal/example/contexts"
+	contextsdigen "github.com/dimes/dihedral/internal/example/contexts/digen"
 	"github.com/dimes/dihedral/internal/example/dbstore"
+	"github.com/dimes/dihedral/internal/example/generics"
+	genericsdigen "github.com/dimes/dihedral/internal/example/generics/digen"
+	"github.com/dimes/dihedral/internal/example/health"
+	healthdigen "github.com/dimes/dihedral/internal/example/health/digen"
+	"github.com/dimes/dihedral/internal/example/injectables"
+	injectablesdigen "github.com/dimes/dihedral/internal/example/injectables/digen"
+	"github.com/dimes/dihedral/internal/example/instances"
+	instancesdigen "github.com/dimes/dihedral/internal/example/instances/digen"
+	"github.com/dimes/dihedral/internal/example/lifecycle"
+	lifecycledigen "github.com/dimes/dihedral/internal/example/lifecycle/digen"
+	"github.com/dimes/dihedral/internal/example/members"
+	membersdigen "github.com/dimes/dihedral/internal/example/members/digen"
+	"github.com/dimes/dihedral/internal/example/params"
+	paramsdigen "github.com/dimes/dihedral/internal/example/params/digen"
+	"github.com/dimes/dihedral/internal/example/plugins"
+	pluginsdigen "github.com/dimes/dihedral/internal/example/plugins/digen"
+	qualifiersdigen "github.com/dimes/dihedral/internal/example/qualifiers/digen"
+	"github.com/dimes/dihedral/internal/example/unnamed"
+	unnameddigen "github.com/dimes/dihedral/internal/example/unnamed/digen"
+	"github.com/dimes/dihedral/internal/example/wrappers"
+	wrappersdigen "github.com/dimes/dihedral/internal/example/wrappers/digen"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,3 +68,877 @@ func TestExampleInjection(t *testing.T) {
 
 	assert.Equal(t, "specific", string(component.GetBoundType()))
 }
+
+func TestSingletonInjection(t *testing.T) {
+	component := digen.NewDihedralServiceComponent(&dbstore.DBProviderModule{
+		Prefix: "Hello",
+	})
+
+	first, err := component.GetService()
+	assert.NoError(t, err)
+
+	second, err := component.GetService()
+	assert.NoError(t, err)
+
+	assert.False(t, first == second)
+	assert.True(t, first.DBStore == second.DBStore)
+
+	assert.NoError(t, first.SetValueInDBStore("Singleton"))
+	assert.Equal(t, "Hello Singleton", second.GetValueFromDBStore())
+
+	otherComponent := digen.NewDihedralServiceComponent(&dbstore.DBProviderModule{
+		Prefix: "Hello",
+	})
+
+	other, err := otherComponent.GetService()
+	assert.NoError(t, err)
+	assert.False(t, first.DBStore == other.DBStore)
+}
+
+func TestConcurrentSingletonInjection(t *testing.T) {
+	module := &concurrency.ConnectionModule{}
+	component := concurrencydigen.NewDihedralConcurrencyComponent(module)
+
+	const goroutines = 64
+	pools := make([]*concurrency.Pool, goroutines)
+	connections := make([]*concurrency.Connection, goroutines)
+	errs := make([]error, goroutines*2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pools[i], errs[i*2] = component.GetPool()
+			connections[i], errs[i*2+1] = component.GetConnection()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	for i := 0; i < goroutines; i++ {
+		assert.True(t, pools[0] == pools[i])
+		assert.True(t, connections[0] == connections[i])
+		assert.True(t, pools[i].Connection == connections[i])
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&module.Attempts))
+}
+
+func TestConcurrentSingletonInjectionThroughProvider(t *testing.T) {
+	module := &concurrency.ConnectionModule{}
+	component := concurrencydigen.NewDihedralConcurrencyComponent(module)
+	dispatcher := component.GetDispatcher()
+
+	const goroutines = 64
+	pools := make([]*concurrency.Pool, goroutines)
+	errs := make([]error, goroutines)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pools[i], errs[i] = dispatcher.Pools.Get()
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < goroutines; i++ {
+		assert.NoError(t, errs[i])
+		assert.True(t, pools[0] == pools[i])
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&module.Attempts))
+}
+
+func TestFailedSingletonIsNotCached(t *testing.T) {
+	module := &concurrency.ConnectionModule{Failures: 1}
+	component := concurrencydigen.NewDihedralConcurrencyComponent(module)
+
+	pool, err := component.GetPool()
+	assert.Error(t, err)
+	assert.Nil(t, pool)
+
+	pool, err = component.GetPool()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), pool.Connection.Attempt)
+
+	connection, err := component.GetConnection()
+	assert.NoError(t, err)
+	assert.True(t, pool.Connection == connection)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&module.Attempts))
+}
+
+func TestSubcomponentInjection(t *testing.T) {
+	component := digen.NewDihedralServiceComponent(&dbstore.DBProviderModule{
+		Prefix: "Hello",
+	})
+
+	firstRequest := component.NewRequestComponent(&bindings.RequestModule{RequestID: "first"})
+	secondRequest := component.NewRequestComponent(&bindings.RequestModule{RequestID: "second"})
+
+	firstHandler, err := firstRequest.GetRequestHandler()
+	assert.NoError(t, err)
+	assert.Equal(t, example.RequestID("first"), firstHandler.RequestID)
+	assert.Equal(t, example.ServiceTimeout(5000000000), firstHandler.Service.ServiceTimeout)
+
+	sameHandler, err := firstRequest.GetRequestHandler()
+	assert.NoError(t, err)
+	assert.True(t, firstHandler == sameHandler)
+
+	secondHandler, err := secondRequest.GetRequestHandler()
+	assert.NoError(t, err)
+	assert.Equal(t, example.RequestID("second"), secondHandler.RequestID)
+	assert.False(t, firstHandler == secondHandler)
+
+	// Singletons of the parent component are shared by all subcomponents
+	service, err := component.GetService()
+	assert.NoError(t, err)
+	assert.True(t, firstHandler.DBStore == secondHandler.DBStore)
+	assert.True(t, firstHandler.DBStore == service.DBStore)
+}
+
+func TestScopedInjection(t *testing.T) {
+	component := digen.NewDihedralServiceComponent(&dbstore.DBProviderModule{
+		Prefix: "Hello",
+	})
+
+	firstRequest := component.NewRequestComponent(&bindings.RequestModule{RequestID: "first"})
+	secondRequest := component.NewRequestComponent(&bindings.RequestModule{RequestID: "second"})
+
+	firstHandler, err := firstRequest.GetRequestHandler()
+	assert.NoError(t, err)
+
+	secondHandler, err := secondRequest.GetRequestHandler()
+	assert.NoError(t, err)
+
+	// Application scoped types are created by the component with the application scope
+	assert.True(t, firstHandler.Counter == secondHandler.Counter)
+	assert.Equal(t, int32(1), firstHandler.Counter.Increment())
+	assert.Equal(t, int32(2), secondHandler.Counter.Increment())
+
+	otherComponent := digen.NewDihedralServiceComponent(&dbstore.DBProviderModule{
+		Prefix: "Hello",
+	})
+	otherHandler, err := otherComponent.NewRequestComponent(&bindings.RequestModule{}).GetRequestHandler()
+	assert.NoError(t, err)
+	assert.False(t, firstHandler.Counter == otherHandler.Counter)
+}
+
+func TestWrapperInjection(t *testing.T) {
+	module := &wrappers.ReportModule{}
+	component := wrappersdigen.NewDihedralWrappersComponent(module)
+
+	dashboard, err := component.GetDashboard()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), module.Created)
+
+	// Lazy creates its value once
+	first, err := dashboard.Report.Get()
+	assert.NoError(t, err)
+	second, err := dashboard.Report.Get()
+	assert.NoError(t, err)
+	assert.True(t, first == second)
+	assert.Equal(t, int32(1), module.Created)
+
+	// Provider creates a new value on every call
+	third, err := dashboard.NewReport.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), third.Number)
+
+	store, err := dashboard.Store.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "memory", store.Name())
+
+	report, err := component.GetReportProvider().Get()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), report.Number)
+}
+
+func TestLazyBreaksCycle(t *testing.T) {
+	component := wrappersdigen.NewDihedralWrappersComponent(&wrappers.ReportModule{})
+
+	parent, err := component.GetParent()
+	assert.NoError(t, err)
+
+	childParent, err := parent.Child.Parent.Get()
+	assert.NoError(t, err)
+	assert.NotNil(t, childParent.Child)
+}
+
+func TestSingletonDependsOnItselfFromAnotherGoroutine(t *testing.T) {
+	component := wrappersdigen.NewDihedralWrappersComponent(&wrappers.ReportModule{})
+
+	_, err := component.GetMonitor()
+	assert.EqualError(t, err, "github.com/dimes/dihedral/internal/example/wrappers.Monitor depends on itself "+
+		"through a Lazy or Provider that is called while it is created")
+}
+
+func TestSingletonProviderMethod(t *testing.T) {
+	module := &wrappers.ReportModule{}
+	component := wrappersdigen.NewDihedralWrappersComponent(module)
+
+	first, err := component.GetSummary()
+	assert.NoError(t, err)
+	second, err := component.GetSummary()
+	assert.NoError(t, err)
+	assert.True(t, first == second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&module.Created))
+
+	// Other providers of the module are not cached
+	dashboard, err := component.GetDashboard()
+	assert.NoError(t, err)
+	report, err := dashboard.NewReport.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), report.Number)
+}
+
+func TestSingletonDependsOnItself(t *testing.T) {
+	component := wrappersdigen.NewDihedralWrappersComponent(&wrappers.ReportModule{})
+
+	_, err := component.GetRegistry()
+	assert.EqualError(t, err, "github.com/dimes/dihedral/internal/example/wrappers.Registry depends on itself "+
+		"through a Lazy or Provider that is called while it is created")
+}
+
+func TestLazyCycleInSubcomponent(t *testing.T) {
+	component := wrappersdigen.NewDihedralWrappersComponent(&wrappers.ReportModule{})
+	request := component.NewRequestComponent(&wrappers.RequestModule{User: "user"})
+
+	session, err := request.GetSession()
+	assert.NoError(t, err)
+
+	pageSession, err := session.Page.Session.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, wrappers.User("user"), pageSession.User)
+
+	page, err := request.GetPage()
+	assert.NoError(t, err)
+	assert.NotNil(t, page)
+}
+
+func TestMultibindingInjection(t *testing.T) {
+	component := healthdigen.NewDihedralHealthComponent()
+
+	service, err := component.GetHealthService()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"database", "uptime"}, service.Names())
+
+	checks, err := component.GetChecks()
+	assert.NoError(t, err)
+	assert.Len(t, checks, 2)
+
+	// Subcomponents receive the contributions of their parent and their own
+	request := component.NewRequestComponent(&health.RequestModule{RequestID: "first"})
+	requestService, err := request.GetHealthService()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"database", "uptime", "request first"}, requestService.Names())
+}
+
+func TestMapMultibindingInjection(t *testing.T) {
+	component := commandsdigen.NewDihedralCommandsComponent()
+
+	cli, err := component.GetCLI()
+	assert.NoError(t, err)
+	assert.Len(t, cli.Commands, 2)
+	assert.Equal(t, "deployed", cli.Commands[commands.Deploy].Run())
+	assert.Equal(t, "rolled back", cli.Commands[commands.Rollback].Run())
+	assert.Len(t, cli.Aliases, 1)
+	assert.Equal(t, "rolled back", cli.Aliases["undo"].Run())
+}
+
+func TestOptionalInjection(t *testing.T) {
+	component := wrappersdigen.NewDihedralWrappersComponent(&wrappers.ReportModule{})
+
+	dashboard, err := component.GetDashboard()
+	assert.NoError(t, err)
+
+	// Theme is not bound, so the optional is empty
+	_, ok := dashboard.Theme.Get()
+	assert.False(t, ok)
+
+	store, ok := dashboard.OptionalStore.Get()
+	assert.True(t, ok)
+	assert.Equal(t, "memory", store.Name())
+
+	assert.Equal(t, wrappers.Title("Dashboard from memory"), dashboard.Title)
+}
+
+func TestQualifiedInjection(t *testing.T) {
+	component := qualifiersdigen.NewDihedralQualifiersComponent()
+
+	repository, err := component.GetRepository()
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", repository.Primary.Name)
+
+	replica, err := repository.Replica.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "replica", replica.Name)
+	assert.Equal(t, "replica", component.GetReplica().Name)
+	assert.Equal(t, "memory", repository.Sessions.Name())
+
+	// Nothing provides the archive database
+	assert.False(t, repository.Archive.Present())
+}
+
+func TestGenericInjection(t *testing.T) {
+	component := genericsdigen.NewDihedralGenericsComponent()
+
+	users := component.GetUsers()
+	users.Store.Save("first", generics.User{Name: "Alice"})
+
+	// Every instantiation has its own singleton cache
+	user, ok := component.GetUserStore().Load("first")
+	assert.True(t, ok)
+	assert.Equal(t, "Alice", user.Name)
+
+	orders := component.GetOrders()
+	orders.Store.Save("first", generics.Order{ID: 1})
+	order, ok := orders.Store.Load("first")
+	assert.True(t, ok)
+	assert.Equal(t, 1, order.ID)
+	assert.Len(t, component.GetSessions().Values, 0)
+}
+
+func TestUnnamedInjection(t *testing.T) {
+	component := unnameddigen.NewDihedralUnnamedComponent()
+
+	config := component.GetConfig()
+	assert.Equal(t, []string{"first", "second"}, config.Hosts)
+	assert.Equal(t, map[string]int{"requests": 100}, config.Limits)
+	assert.Equal(t, time.Unix(0, 0), config.Now())
+	assert.Equal(t, 3, *config.Retries)
+	assert.Equal(t, 8100, config.Port)
+	assert.NotNil(t, config.Buffer)
+
+	backups, err := config.Backups.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backup"}, backups)
+	assert.Equal(t, config.Hosts, component.GetHosts())
+
+	component.GetEvents() <- unnamed.Event{Name: "started"}
+	assert.Equal(t, "started", (<-config.Events).Name)
+}
+
+func TestConstructorInjection(t *testing.T) {
+	component := constructorsdigen.NewDihedralConstructorsComponent(&constructors.ConfigModule{
+		URL: "https://example.com/api",
+	})
+
+	service, err := component.GetService()
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", service.Client.Endpoint.Host)
+	assert.Equal(t, 3, service.Client.Policy.Attempts)
+
+	client, err := component.GetClient()
+	assert.NoError(t, err)
+	assert.Equal(t, "/api", client.Endpoint.Path)
+}
+
+func TestStructConstructorInjection(t *testing.T) {
+	component := constructorsdigen.NewDihedralConstructorsComponent(&constructors.ConfigModule{
+		URL: "https://example.com/api",
+	})
+
+	pool, err := component.GetPool()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, pool.Size())
+
+	component = constructorsdigen.NewDihedralConstructorsComponent(&constructors.ConfigModule{
+		URL: "relative/path",
+	})
+	_, err = component.GetPool()
+	assert.EqualError(t, err, "Endpoint relative/path is not absolute")
+
+	// Settings skip NewSettings, so their fields are injected
+	settings, err := component.GetSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, settings.Policy.Attempts)
+}
+
+func TestConstructorErrorPropagation(t *testing.T) {
+	component := constructorsdigen.NewDihedralConstructorsComponent(&constructors.ConfigModule{
+		URL: "://invalid",
+	})
+	_, err := component.GetService()
+	assert.Error(t, err)
+
+	component = constructorsdigen.NewDihedralConstructorsComponent(&constructors.ConfigModule{
+		URL: "relative/path",
+	})
+	_, err = component.GetClient()
+	assert.EqualError(t, err, "Endpoint relative/path is not absolute")
+}
+
+func TestThirdPartyInjection(t *testing.T) {
+	component := injectablesdigen.NewDihedralInjectablesComponent()
+
+	app := component.GetApp()
+	assert.Equal(t, "primary", app.Server.Client.Transport.Name)
+	assert.Zero(t, app.Server.Client.Timeout)
+
+	client, err := component.GetClient()
+	assert.NoError(t, err)
+	client.Logger.Log("sent")
+	assert.Equal(t, []string{"sent"}, client.Logger.(*injectables.MemoryLogger).Messages())
+}
+
+func TestInitHooks(t *testing.T) {
+	module := &lifecycle.ConfigModule{}
+	component := lifecycledigen.NewDihedralLifecycleComponent(module)
+
+	service, err := component.GetService()
+	assert.EqualError(t, err, "No database URL")
+	assert.Nil(t, service)
+	assert.Empty(t, component.GetLog().Entries())
+
+	// The database is not cached if its initialization fails
+	module.URL = "postgres://localhost"
+	service, err = component.GetService()
+	assert.NoError(t, err)
+	assert.True(t, service.Database.Connected())
+	assert.Equal(t, []string{"database initialized", "service initialized"}, component.GetLog().Entries())
+}
+
+func TestCloseCleansUpInReverseOrder(t *testing.T) {
+	component := lifecycledigen.NewDihedralLifecycleComponent(&lifecycle.ConfigModule{
+		URL: "postgres://localhost",
+	})
+
+	service, err := component.GetService()
+	assert.NoError(t, err)
+	assert.True(t, service.Listener.Open)
+
+	assert.NoError(t, component.Close())
+	assert.False(t, service.Listener.Open)
+	assert.False(t, service.Database.Connected())
+	assert.Equal(t, []string{
+		"database initialized",
+		"service initialized",
+		"listener closed",
+		"database closed",
+	}, service.Log.Entries())
+
+	// Resources are only cleaned up once
+	assert.NoError(t, component.Close())
+	assert.Len(t, service.Log.Entries(), 4)
+}
+
+func TestCloseClosesProvidedClosers(t *testing.T) {
+	component := lifecycledigen.NewDihedralLifecycleComponent(&lifecycle.ConfigModule{
+		URL: "postgres://localhost",
+	})
+	log := component.GetLog()
+
+	store, err := component.GetStore()
+	assert.NoError(t, err)
+	assert.True(t, store.Open)
+
+	assert.NoError(t, component.Close())
+	assert.False(t, store.Open)
+	assert.Equal(t, []string{"store closed"}, log.Entries())
+}
+
+func TestFailedProviderRollsBackEarlierProviders(t *testing.T) {
+	module := &lifecycle.ConfigModule{
+		URL:         "postgres://localhost",
+		FailClose:   true,
+		FailConnect: true,
+	}
+	component := lifecycledigen.NewDihedralLifecycleComponent(module)
+	log := component.GetLog()
+
+	// The lease is released once the connection fails, and both errors are returned
+	transfer, err := component.GetTransfer()
+	assert.Nil(t, transfer)
+	assert.EqualError(t, err, "Connect failed; Release failed")
+	assert.True(t, errors.Is(err, lifecycle.ErrConnectFailed))
+	assert.True(t, errors.Is(err, lifecycle.ErrReleaseFailed))
+	assert.Equal(t, []string{"lease released"}, log.Entries())
+
+	// The lease of the failed transfer is not released again
+	assert.NoError(t, component.Close())
+	assert.Equal(t, []string{"lease released"}, log.Entries())
+}
+
+func TestNilCleanupFunctionsAreIgnored(t *testing.T) {
+	component := lifecycledigen.NewDihedralLifecycleComponent(&lifecycle.ConfigModule{
+		URL: "postgres://localhost",
+	})
+	log := component.GetLog()
+
+	// The token of the transfer is provided with a nil cleanup function
+	transfer, err := component.GetTransfer()
+	assert.NoError(t, err)
+	assert.Equal(t, "token", transfer.Token.Value)
+
+	assert.NoError(t, component.Close())
+	assert.False(t, transfer.Lease.Held)
+	assert.Equal(t, []string{"lease released"}, log.Entries())
+}
+
+func TestCloseCleansUpEveryCall(t *testing.T) {
+	component := lifecycledigen.NewDihedralLifecycleComponent(&lifecycle.ConfigModule{
+		URL: "postgres://localhost",
+	})
+	log := component.GetLog()
+
+	// Sessions are not cached, so the component keeps every session until it is closed,
+	// unless the target returns a cleanup function
+	workers := make([]*lifecycle.Worker, 0)
+	for i := 0; i < 3; i++ {
+		worker, err := component.GetWorker()
+		assert.NoError(t, err)
+		workers = append(workers, worker)
+	}
+
+	assert.NoError(t, component.Close())
+	for _, worker := range workers {
+		assert.False(t, worker.Session.Open)
+	}
+	assert.Equal(t, []string{
+		"database initialized",
+		"session closed",
+		"session closed",
+		"session closed",
+		"database closed",
+	}, log.Entries())
+
+	// Closed singletons are not handed out anymore
+	worker, err := component.GetWorker()
+	assert.True(t, errors.Is(err, inject.ErrClosed))
+	assert.Nil(t, worker)
+	assert.Panics(t, func() { component.GetLog() })
+	assert.Len(t, log.Entries(), 5)
+}
+
+func TestTargetReturnsCleanup(t *testing.T) {
+	component := lifecycledigen.NewDihedralLifecycleComponent(&lifecycle.ConfigModule{
+		URL: "postgres://localhost",
+	})
+	log := component.GetLog()
+
+	// The session of the worker is cleaned up by the caller instead of the component
+	worker, cleanup, err := component.OpenWorker()
+	assert.NoError(t, err)
+	assert.True(t, worker.Session.Open)
+	assert.NoError(t, cleanup())
+	assert.False(t, worker.Session.Open)
+	assert.True(t, worker.Database.Connected())
+	assert.Equal(t, []string{"database initialized", "session closed"}, log.Entries())
+
+	// The database is a singleton, so it is still owned by the component
+	assert.NoError(t, component.Close())
+	assert.Equal(t, []string{"database initialized", "session closed", "database closed"}, log.Entries())
+
+	worker, cleanup, err = component.OpenWorker()
+	assert.True(t, errors.Is(err, inject.ErrClosed))
+	assert.Nil(t, worker)
+	assert.Nil(t, cleanup)
+}
+
+func TestCloseAggregatesErrors(t *testing.T) {
+	var component lifecycle.LifecycleComponent = lifecycledigen.NewDihedralLifecycleComponent(&lifecycle.ConfigModule{
+		URL:       "postgres://localhost",
+		FailClose: true,
+	})
+
+	_, err := component.GetService()
+	assert.NoError(t, err)
+
+	err = component.Close()
+	assert.EqualError(t, err, "Listener close failed; Database close failed")
+
+	cleanupErr, ok := err.(*inject.CleanupError)
+	assert.True(t, ok)
+	assert.Len(t, cleanupErr.Errors, 2)
+}
+
+func TestFailedResolutionRollsBack(t *testing.T) {
+	module := &lifecycle.ConfigModule{
+		URL:        "postgres://localhost",
+		FailWorker: true,
+	}
+	component := lifecycledigen.NewDihedralLifecycleComponent(module)
+	log := component.GetLog()
+
+	worker, err := component.GetWorker()
+	assert.EqualError(t, err, "Worker failed")
+	assert.Nil(t, worker)
+	assert.Equal(t, []string{"database initialized", "session closed"}, log.Entries())
+
+	// The database is a singleton, so it is owned by the component once it is created
+	module.FailWorker = false
+	worker, err = component.GetWorker()
+	assert.NoError(t, err)
+	assert.True(t, worker.Session.Open)
+	assert.True(t, worker.Database.Connected())
+
+	assert.NoError(t, component.Close())
+	assert.False(t, worker.Session.Open)
+	assert.Equal(t, []string{
+		"database initialized",
+		"session closed",
+		"session closed",
+		"database closed",
+	}, log.Entries())
+}
+
+func TestStartAndStopInDependencyOrder(t *testing.T) {
+	var component background.BackgroundComponent = backgrounddigen.NewDihedralBackgroundComponent(
+		&background.OptionsModule{Options: &background.Options{}})
+
+	component.GetScheduler()
+	events := component.GetEvents()
+	assert.NoError(t, component.Start(context.Background()))
+	assert.Equal(t, []string{"queue started", "consumer started", "scheduler started"}, events.List())
+
+	// Values are only started once
+	assert.NoError(t, component.Start(context.Background()))
+	assert.Len(t, events.List(), 3)
+
+	assert.NoError(t, component.Stop(context.Background()))
+	assert.Equal(t, []string{
+		"queue started",
+		"consumer started",
+		"scheduler started",
+		"scheduler stopped",
+		"consumer stopped",
+		"queue stopped",
+	}, events.List())
+
+	assert.NoError(t, component.Stop(context.Background()))
+	assert.Len(t, events.List(), 6)
+}
+
+func TestStopAggregatesErrors(t *testing.T) {
+	component := backgrounddigen.NewDihedralBackgroundComponent(
+		&background.OptionsModule{Options: &background.Options{FailStop: true}})
+
+	component.GetScheduler()
+	assert.NoError(t, component.Start(context.Background()))
+	assert.EqualError(t, component.Stop(context.Background()), "Consumer stop failed; Queue stop failed")
+}
+
+func TestFailedStartStopsStartedValues(t *testing.T) {
+	options := &background.Options{FailStart: true}
+	component := backgrounddigen.NewDihedralBackgroundComponent(&background.OptionsModule{Options: options})
+
+	component.GetScheduler()
+	events := component.GetEvents()
+	assert.EqualError(t, component.Start(context.Background()), "Scheduler start failed")
+	assert.Equal(t, []string{
+		"queue started",
+		"consumer started",
+		"consumer stopped",
+		"queue stopped",
+	}, events.List())
+
+	// The stopped values are started again by the next call
+	options.FailStart = false
+	assert.NoError(t, component.Start(context.Background()))
+	assert.Equal(t, []string{"queue started", "consumer started", "scheduler started"}, events.List()[4:])
+}
+
+func TestFailedStartAggregatesStopErrors(t *testing.T) {
+	component := backgrounddigen.NewDihedralBackgroundComponent(
+		&background.OptionsModule{Options: &background.Options{FailStart: true, FailStop: true}})
+
+	component.GetScheduler()
+	err := component.Start(context.Background())
+	assert.EqualError(t, err, "Scheduler start failed; Consumer stop failed; Queue stop failed")
+
+	// The values are not stopped again
+	assert.NoError(t, component.Stop(context.Background()))
+}
+
+func TestStartTimesOut(t *testing.T) {
+	component := backgrounddigen.NewDihedralBackgroundComponent(
+		&background.OptionsModule{Options: &background.Options{DelayStart: true}})
+
+	component.GetScheduler()
+	events := component.GetEvents()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := component.Start(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// The scheduler starts after the timeout, so it is stopped along with the other values
+	assert.Equal(t, []string{
+		"queue started",
+		"consumer started",
+		"scheduler started",
+		"scheduler stopped",
+		"consumer stopped",
+		"queue stopped",
+	}, events.List())
+	assert.NoError(t, component.Stop(context.Background()))
+	assert.Len(t, events.List(), 6)
+}
+
+func TestStopTimesOut(t *testing.T) {
+	component := backgrounddigen.NewDihedralBackgroundComponent(
+		&background.OptionsModule{Options: &background.Options{BlockStop: true}})
+
+	component.GetScheduler()
+	assert.NoError(t, component.Start(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := component.Stop(ctx)
+
+	// The values after the scheduler are not stopped once the context is done
+	cleanupErr, ok := err.(*inject.CleanupError)
+	assert.True(t, ok)
+	assert.Equal(t, []error{
+		context.DeadlineExceeded,
+		context.DeadlineExceeded,
+		context.DeadlineExceeded,
+	}, cleanupErr.Errors)
+}
+
+func TestContextInjection(t *testing.T) {
+	component := contextsdigen.NewDihedralContextsComponent()
+
+	ctx, cancel := context.WithCancel(contexts.WithTenant(context.Background(), "acme"))
+	handler, err := component.GetHandler(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, contexts.Tenant("acme"), handler.Connection.Tenant)
+	assert.True(t, handler.Connection.Opened())
+
+	// Providers keep the context of the target that created them
+	connection, err := handler.Connections()
+	assert.NoError(t, err)
+	assert.Equal(t, contexts.Tenant("acme"), connection.Tenant)
+
+	cancel()
+	connection, err = handler.Connections()
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, connection)
+
+	// Targets without a context resolve with the background context
+	tenant, err := component.GetTenant()
+	assert.EqualError(t, err, "No tenant in context")
+	assert.Empty(t, tenant)
+}
+
+func TestAssistedInjection(t *testing.T) {
+	component := assisteddigen.NewDihedralAssistedComponent()
+
+	router, err := component.GetRouter()
+	assert.NoError(t, err)
+
+	first, err := router.NewHandler("first", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, assisted.UserID("first"), first.UserID)
+	assert.Equal(t, 10, first.Limit)
+	assert.True(t, first.Database == router.Database)
+
+	newHandler, err := component.GetHandlerFactory()
+	assert.NoError(t, err)
+	second, err := newHandler("second", 20)
+	assert.NoError(t, err)
+	assert.False(t, first == second)
+	assert.Equal(t, assisted.UserID("second"), second.UserID)
+	assert.True(t, second.Database == router.Database)
+
+	// Init hooks see the assisted fields
+	handler, err := newHandler("", 0)
+	assert.EqualError(t, err, "No user ID")
+	assert.Nil(t, handler)
+}
+
+func TestTargetParams(t *testing.T) {
+	component := paramsdigen.NewDihedralParamsComponent()
+
+	first, err := http.NewRequest(http.MethodGet, "/first", nil)
+	assert.NoError(t, err)
+	first.Header.Set("X-User", "alice")
+
+	second, err := http.NewRequest(http.MethodGet, "/second", nil)
+	assert.NoError(t, err)
+	second.Header.Set("X-User", "bob")
+
+	firstHandler, err := component.GetHandler(first)
+	assert.NoError(t, err)
+	assert.True(t, firstHandler.Request == first)
+	assert.Equal(t, params.User("alice"), firstHandler.User)
+
+	secondHandler, err := component.GetHandler(second)
+	assert.NoError(t, err)
+	assert.True(t, secondHandler.Request == second)
+	assert.Equal(t, params.User("bob"), secondHandler.User)
+	assert.True(t, firstHandler.Templates == secondHandler.Templates)
+
+	// Providers keep the parameters of the target that created them
+	session, err := firstHandler.Sessions()
+	assert.NoError(t, err)
+	assert.True(t, session.Request == first)
+
+	job, err := component.GetJob(context.Background(), params.Priority(3))
+	assert.NoError(t, err)
+	assert.Equal(t, params.Priority(3), job.Priority)
+	assert.True(t, job.Templates == firstHandler.Templates)
+
+	// Parameters of interface types can be nil
+	report, err := component.GetReport(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, report.Output)
+}
+
+func TestMembersInjection(t *testing.T) {
+	component := membersdigen.NewDihedralMembersComponent()
+
+	handler := &members.Handler{Path: "/users"}
+	component.InjectHandler(handler)
+	assert.Equal(t, members.Greeting("Hello"), handler.Greeting)
+	assert.Equal(t, "/users", handler.Path)
+
+	database, err := component.GetDatabase()
+	assert.NoError(t, err)
+	assert.True(t, handler.Database == database)
+
+	// Fields are not assigned if resolving one of them fails
+	admin := &members.Admin{}
+	assert.Error(t, component.InjectAdmin(admin))
+	assert.Equal(t, members.Greeting(""), admin.Greeting)
+}
+
+func TestComponentInjection(t *testing.T) {
+	component := pluginsdigen.NewDihedralPluginsComponent()
+
+	loader, err := component.GetLoader()
+	assert.NoError(t, err)
+	assert.True(t, loader.Component == plugins.PluginsComponent(component))
+
+	greeter, err := loader.Lookup()
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", greeter.Greet())
+
+	// Subcomponents inject themselves and their parents
+	request := component.NewRequestComponent()
+	session, err := request.GetSession()
+	assert.NoError(t, err)
+	assert.True(t, session.Request == request)
+	assert.True(t, session.Plugins == plugins.PluginsComponent(component))
+}
+
+func TestInstances(t *testing.T) {
+	config := instances.Config{Address: ":8080"}
+	logger := log.New(io.Discard, "", 0)
+	hosts := []string{"first", "second"}
+	component := instancesdigen.NewDihedralInstancesComponent(config, logger, hosts)
+
+	server, err := component.GetServer()
+	assert.NoError(t, err)
+	assert.Equal(t, config, server.Config)
+	assert.Equal(t, instances.Address(":8080"), server.Address)
+	assert.True(t, server.Logger == logger)
+	assert.Equal(t, hosts, server.Hosts)
+	assert.True(t, component.GetLogger() == logger)
+}
@@ -9,3 +9,18 @@ type Inject struct {
 // parameter to a module to indicate it should be a parameter of the component
 type ProvidedModule struct {
 }
+
+// Singleton is an empty struct that can be added as a non-exported parameter
+// to an injectable struct or a provider module to indicate that only one instance
+// of the type (or of each type provided by the module) should be created per component.
+// Single provider methods are marked with a `//di:singleton` directive instead.
+type Singleton struct {
+}
+
+// Scope is an empty struct that can be added as a non-exported parameter to a
+// struct to declare that struct as a scope. Scopes can be added as non-exported
+// parameters to injectable structs and provider modules. Their instances are then
+// created once per component whose definition returns the scope from its Scope()
+// method.
+type Scope struct {
+}
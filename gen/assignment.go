@@ -3,19 +3,45 @@ package gen
 import (
 	"fmt"
 	"go/types"
+	"strconv"
+	"strings"
 
 	"github.com/dimes/dihedral/resolver"
 	"github.com/dimes/dihedral/typeutil"
 )
 
+const (
+	providerPrefix  = "provides_"
+	singletonPrefix = "singleton_"
+	instancePrefix  = "instance_"
+)
+
 // FactoryName returns the name of the factory function for the given name
 func FactoryName(typeName *types.Named) string {
 	return "factory_" + SanitizeName(typeName)
 }
 
-// ProviderName returns the name of the provider function for the given name
-func ProviderName(typeName *types.Named) string {
-	return "provides_" + SanitizeName(typeName)
+// ProviderName returns the name of the provider function for the given type and qualifier
+func ProviderName(providedType types.Type, qualifier string) string {
+	return providerPrefix + qualifiedName(providedType, qualifier)
+}
+
+// SingletonName returns the name of the component field that caches the singleton
+// instance of the given name
+func SingletonName(typeName *types.Named) string {
+	return singletonPrefix + SanitizeName(typeName)
+}
+
+// InstanceName returns the name of the component field, and of the constructor parameter,
+// that holds the given instance
+func InstanceName(instance *resolver.InstanceResolvedType) string {
+	return instancePrefix + qualifiedName(instance.Type, "")
+}
+
+// ContributionName returns the name used for the provider method of a multibinding
+// contribution. It is unique for every module method.
+func ContributionName(provider *resolver.ModuleResolvedType) string {
+	return SanitizeName(provider.Module.Name) + "_" + provider.Method.Name()
 }
 
 // Assignment represents a way of getting a injected value, either by a provider
@@ -25,23 +51,28 @@ type Assignment interface {
 	// the assignment is not cast to any type.
 	CastTo() *types.Named
 
-	// GetSourceAssignment returns the assignment as a string of source code.
-	GetSourceAssignment() string
+	// Packages returns the packages, other than the package of CastTo, that have to be
+	// imported by the source of this assignment
+	Packages() []*types.Package
+
+	// GetSourceAssignment returns the assignment as a string of source code. The imports
+	// map the paths of imported packages to their import names.
+	GetSourceAssignment(imports map[string]string) string
 }
 
 type factoryAssignment struct {
 	componentReceiverName string
-	typeName              *types.Named
+	factoryName           string
 }
 
 // NewFactoryAssignment returns a factory-method based assignment
 func NewFactoryAssignment(
 	componentReceiverName string,
-	typeName *types.Named,
+	factoryName string,
 ) Assignment {
 	return &factoryAssignment{
 		componentReceiverName: componentReceiverName,
-		typeName:              typeName,
+		factoryName:           factoryName,
 	}
 }
 
@@ -49,25 +80,29 @@ func (f *factoryAssignment) CastTo() *types.Named {
 	return nil
 }
 
-func (f *factoryAssignment) GetSourceAssignment() string {
-	return FactoryName(f.typeName) + "(" + f.componentReceiverName + ")"
+func (f *factoryAssignment) Packages() []*types.Package {
+	return nil
+}
+
+func (f *factoryAssignment) GetSourceAssignment(imports map[string]string) string {
+	return f.factoryName + "(" + f.componentReceiverName + ", " + resolutionParamName + ")"
 }
 
 type providerAssignment struct {
 	componentReceiverName string
-	typeName              *types.Named
+	providerName          string
 	castTo                *types.Named
 }
 
 // NewProviderAssignment returns a component provided assignment
 func NewProviderAssignment(
 	componentReceiverName string,
-	typeName *types.Named,
+	providerName string,
 	castTo *types.Named,
 ) Assignment {
 	return &providerAssignment{
 		componentReceiverName: componentReceiverName,
-		typeName:              typeName,
+		providerName:          providerName,
 		castTo:                castTo,
 	}
 }
@@ -76,47 +111,486 @@ func (p *providerAssignment) CastTo() *types.Named {
 	return p.castTo
 }
 
-func (p *providerAssignment) GetSourceAssignment() string {
-	return p.componentReceiverName + "." + ProviderName(p.typeName) + "()"
+func (p *providerAssignment) Packages() []*types.Package {
+	return nil
+}
+
+func (p *providerAssignment) GetSourceAssignment(imports map[string]string) string {
+	return p.componentReceiverName + "." + p.providerName + "(" + resolutionParamName + ")"
+}
+
+// instanceAssignment assigns an instance that was passed to the constructor of the
+// component. The source has the form:
+//
+//	component.instance_Type, error(nil)
+type instanceAssignment struct {
+	componentReceiverName string
+	instanceName          string
+	castTo                *types.Named
+}
+
+func (i *instanceAssignment) CastTo() *types.Named {
+	return i.castTo
+}
+
+func (i *instanceAssignment) Packages() []*types.Package {
+	return nil
+}
+
+func (i *instanceAssignment) GetSourceAssignment(imports map[string]string) string {
+	return i.componentReceiverName + "." + i.instanceName + ", error(nil)"
+}
+
+// castAssignment overrides the type an assignment is cast to
+type castAssignment struct {
+	Assignment
+	castTo *types.Named
+}
+
+func (c *castAssignment) CastTo() *types.Named {
+	return c.castTo
+}
+
+// wrapperAssignment assigns an inject.Lazy or inject.Provider that calls the wrapped
+// assignment in a resolution of its own, since it is called after the resolution of
+// the value it is assigned to has finished. The new resolution carries the context of
+// that resolution. The source has the form:
+//
+//	inject.Provider[*Type](func() (*Type, error) {
+//	    return inject.Resolve(resolution.Context(), &component.cleanups, func(resolution *inject.Cleanups) (*Type, error) {
+//	        return factory_Type(component, resolution)
+//	    })
+//	}), error(nil)
+type wrapperAssignment struct {
+	componentReceiverName string
+	wrapped               Assignment
+	wrapper               *types.Named
+	wrappedType           types.Type
+}
+
+func (w *wrapperAssignment) CastTo() *types.Named {
+	return nil
+}
+
+func (w *wrapperAssignment) Packages() []*types.Package {
+	packages := append(typePackages(w.wrapper), w.wrapped.Packages()...)
+	if castTo := w.wrapped.CastTo(); castTo != nil {
+		packages = append(packages, typePackages(castTo)...)
+	}
+
+	return packages
+}
+
+func (w *wrapperAssignment) GetSourceAssignment(imports map[string]string) string {
+	wrappedType := typeSource(w.wrappedType, imports)
+	injectImport := imports[w.wrapper.Obj().Pkg().Path()]
+
+	var builder strings.Builder
+	if typeutil.IsLazy(w.wrapper) {
+		builder.WriteString(injectImport + ".NewLazy[" + wrappedType + "](")
+	} else {
+		builder.WriteString(injectImport + ".Provider[" + wrappedType + "](")
+	}
+
+	builder.WriteString("func() (" + wrappedType + ", error) {\n")
+	builder.WriteString(
+		"\t\treturn " + injectImport + ".Resolve(" + resolutionContext + ", &" + w.componentReceiverName + "." +
+			cleanupsFieldName + ", func(" + resolutionParam(imports) + ") (" + wrappedType + ", error) {\n")
+	if castTo := w.wrapped.CastTo(); castTo != nil {
+		builder.WriteString("\t\tobj, err := " + w.wrapped.GetSourceAssignment(imports) + "\n")
+		builder.WriteString("\t\tif err != nil {\n")
+		builder.WriteString("\t\t\tvar zeroValue " + wrappedType + "\n")
+		builder.WriteString("\t\t\treturn zeroValue, err\n")
+		builder.WriteString("\t\t}\n")
+		builder.WriteString("\t\treturn (" + typeSource(castTo, imports) + ")(obj), nil\n")
+	} else {
+		builder.WriteString("\t\treturn " + w.wrapped.GetSourceAssignment(imports) + "\n")
+	}
+	builder.WriteString("\t\t})\n")
+	builder.WriteString("\t}), error(nil)")
+
+	return builder.String()
+}
+
+// assistedAssignment assigns an assisted factory, which creates a struct with its assisted
+// fields set to the parameters of the factory. Like wrapperAssignment, the struct is
+// created in a resolution of its own. The source has the form:
+//
+//	func(assisted0 UserID) (*Type, error) {
+//	    return inject.Resolve(resolution.Context(), &component.cleanups, func(resolution *inject.Cleanups) (*Type, error) {
+//	        return factory_Type(component, resolution, assisted0)
+//	    })
+//	}, error(nil)
+type assistedAssignment struct {
+	componentReceiverName string
+	factoryName           string
+	factoryType           *types.Signature
+}
+
+func (a *assistedAssignment) CastTo() *types.Named {
+	return nil
+}
+
+func (a *assistedAssignment) Packages() []*types.Package {
+	return append(typePackages(a.factoryType), injectPackage())
+}
+
+func (a *assistedAssignment) GetSourceAssignment(imports map[string]string) string {
+	params := a.factoryType.Params()
+	paramDeclarations := make([]string, 0, params.Len())
+	paramNames := make([]string, 0, params.Len())
+	for i := 0; i < params.Len(); i++ {
+		paramDeclarations = append(paramDeclarations,
+			assistedParamName(i)+" "+typeSource(params.At(i).Type(), imports))
+		paramNames = append(paramNames, assistedParamName(i))
+	}
+
+	returnType := typeSource(a.factoryType.Results().At(0).Type(), imports)
+
+	var builder strings.Builder
+	builder.WriteString("func(" + strings.Join(paramDeclarations, ", ") + ") (" + returnType + ", error) {\n")
+	builder.WriteString(
+		"\t\treturn " + imports[cleanupsType.PkgPath()] + ".Resolve(" + resolutionContext + ", &" +
+			a.componentReceiverName + "." + cleanupsFieldName + ", func(" + resolutionParam(imports) + ") (" +
+			returnType + ", error) {\n")
+	builder.WriteString("\t\treturn " + a.factoryName + "(" + a.componentReceiverName + ", " +
+		resolutionParamName + ", " + strings.Join(paramNames, ", ") + ")\n")
+	builder.WriteString("\t\t})\n")
+	builder.WriteString("\t}, error(nil)")
+
+	return builder.String()
+}
+
+// paramAssignment assigns a parameter of the target method, which the context of the
+// resolution carries. The source has the form:
+//
+//	inject.Param[*Type](resolution, "Type")
+type paramAssignment struct {
+	paramType types.Type
+}
+
+func (p *paramAssignment) CastTo() *types.Named {
+	return nil
+}
+
+func (p *paramAssignment) Packages() []*types.Package {
+	return append(typePackages(p.paramType), injectPackage())
+}
+
+func (p *paramAssignment) GetSourceAssignment(imports map[string]string) string {
+	return imports[cleanupsType.PkgPath()] + ".Param[" + typeSource(p.paramType, imports) + "](" +
+		resolutionParamName + ", " + strconv.Quote(typeutil.TypeID(p.paramType)) + ")"
+}
+
+// contextAssignment assigns the context of the resolution, which is passed to the
+// component by the target method. The source has the form:
+//
+//	resolution.Context(), error(nil)
+type contextAssignment struct{}
+
+func (c *contextAssignment) CastTo() *types.Named {
+	return nil
+}
+
+func (c *contextAssignment) Packages() []*types.Package {
+	return nil
+}
+
+func (c *contextAssignment) GetSourceAssignment(imports map[string]string) string {
+	return resolutionContext + ", error(nil)"
+}
+
+// componentAssignment assigns the generated component, or one of its parents, in place of
+// its target interface. The source has the form:
+//
+//	component, error(nil)
+type componentAssignment struct {
+	componentReceiverName string
+}
+
+func (c *componentAssignment) CastTo() *types.Named {
+	return nil
+}
+
+func (c *componentAssignment) Packages() []*types.Package {
+	return nil
+}
+
+func (c *componentAssignment) GetSourceAssignment(imports map[string]string) string {
+	return c.componentReceiverName + ", error(nil)"
+}
+
+// optionalAssignment assigns an inject.Optional. If the wrapped type is bound, the
+// source has the form:
+//
+//	func() (inject.Optional[*Type], error) {
+//	    obj, err := factory_Type(component, resolution)
+//	    if err != nil {
+//	        return inject.Optional[*Type]{}, err
+//	    }
+//	    return inject.NewOptional[*Type](obj), nil
+//	}()
+//
+// Otherwise, the wrapped assignment is nil and an empty Optional is assigned.
+type optionalAssignment struct {
+	wrapped     Assignment
+	wrapper     *types.Named
+	wrappedType types.Type
+}
+
+func (o *optionalAssignment) CastTo() *types.Named {
+	return nil
+}
+
+func (o *optionalAssignment) Packages() []*types.Package {
+	packages := typePackages(o.wrapper)
+	if o.wrapped == nil {
+		return packages
+	}
+
+	packages = append(packages, o.wrapped.Packages()...)
+	if castTo := o.wrapped.CastTo(); castTo != nil {
+		packages = append(packages, typePackages(castTo)...)
+	}
+
+	return packages
+}
+
+func (o *optionalAssignment) GetSourceAssignment(imports map[string]string) string {
+	wrappedType := typeSource(o.wrappedType, imports)
+	injectImport := imports[o.wrapper.Obj().Pkg().Path()]
+	optionalType := injectImport + ".Optional[" + wrappedType + "]"
+	if o.wrapped == nil {
+		return optionalType + "{}, error(nil)"
+	}
+
+	value := "obj"
+	if castTo := o.wrapped.CastTo(); castTo != nil {
+		value = "(" + typeSource(castTo, imports) + ")(obj)"
+	}
+
+	var builder strings.Builder
+	builder.WriteString("func() (" + optionalType + ", error) {\n")
+	builder.WriteString("\t\tobj, err := " + o.wrapped.GetSourceAssignment(imports) + "\n")
+	builder.WriteString("\t\tif err != nil {\n")
+	builder.WriteString("\t\t\treturn " + optionalType + "{}, err\n")
+	builder.WriteString("\t\t}\n")
+	builder.WriteString("\t\treturn " + injectImport + ".NewOptional[" + wrappedType + "](" + value + "), nil\n")
+	builder.WriteString("\t}()")
+
+	return builder.String()
 }
 
-// AssignmentForFieldType returns an assignment for the given field type
+// AssignmentForFieldType returns an assignment for the given field type and qualifier.
+// Types that are not local to the graph are assigned from the parent component, and
+// context.Context and the parameters of target methods are assigned from the resolution.
+// The target interface of a component is assigned the component itself.
 func AssignmentForFieldType(
 	componentReceiverName string,
 	rawFieldType types.Type,
-	providers map[string]resolver.ResolvedType,
-	bindings map[string]*types.Named,
+	qualifier string,
+	graph *Graph,
 ) (Assignment, error) {
-	var fieldName *types.Named
-	switch fieldType := rawFieldType.(type) {
-	case *types.Named:
-		fieldName = fieldType
-	case *types.Pointer:
-		fieldName = fieldType.Elem().(*types.Named)
-	default:
-		return nil, fmt.Errorf("Field %+v is not a supported type", fieldType)
+	if typeutil.IsContext(rawFieldType) {
+		return &contextAssignment{}, nil
+	}
+
+	if depth := graph.ComponentDepth(rawFieldType); depth >= 0 && qualifier == "" {
+		return &componentAssignment{
+			componentReceiverName: componentReceiverName + strings.Repeat("."+parentFieldName, depth),
+		}, nil
+	}
+
+	if paramType := graph.Param(rawFieldType); paramType != nil && qualifier == "" {
+		if !types.Identical(paramType, rawFieldType) {
+			return nil, fmt.Errorf("Expected %+v to be injected as the parameter type %+v", rawFieldType, paramType)
+		}
+
+		return &paramAssignment{paramType: paramType}, nil
+	}
+
+	if wrappedType, wrapper := typeutil.UnwrapType(rawFieldType); wrapper != nil {
+		if typeutil.IsOptional(wrapper) && !graph.isBound(wrappedType, qualifier) {
+			return &optionalAssignment{
+				wrapper:     wrapper,
+				wrappedType: wrappedType,
+			}, nil
+		}
+
+		wrapped, err := AssignmentForFieldType(componentReceiverName, wrappedType, qualifier, graph)
+		if err != nil {
+			return nil, err
+		}
+
+		if typeutil.IsOptional(wrapper) {
+			return &optionalAssignment{
+				wrapped:     wrapped,
+				wrapper:     wrapper,
+				wrappedType: wrappedType,
+			}, nil
+		}
+
+		return &wrapperAssignment{
+			componentReceiverName: componentReceiverName,
+			wrapped:               wrapped,
+			wrapper:               wrapper,
+			wrappedType:           wrappedType,
+		}, nil
+	}
+
+	fieldName := namedFromType(rawFieldType)
+	if fieldName == nil {
+		return assignmentForUnnamed(componentReceiverName, rawFieldType, qualifier, graph)
 	}
 
 	var castTo *types.Named
-	fieldID := typeutil.IDFromNamed(fieldName)
-	if binding := bindings[fieldID]; binding != nil {
+	fieldID := typeutil.QualifiedID(fieldName, qualifier)
+	if binding := graph.Binding(fieldID); binding != nil {
 		if fieldName != binding {
 			castTo = fieldName
 		}
 
 		fieldID = typeutil.IDFromNamed(binding)
 		fieldName = binding
+		qualifier = ""
 	}
 
-	if provider := providers[fieldID]; provider != nil {
-		typedProvider, ok := provider.(*resolver.ModuleResolvedType)
-		if ok {
-			fieldName = typedProvider.Name
-			return NewProviderAssignment(componentReceiverName, fieldName, castTo), nil
+	if !graph.isLocal(fieldName, qualifier) {
+		parentAssignment, err := AssignmentForFieldType(
+			componentReceiverName+"."+parentFieldName,
+			fieldName,
+			qualifier,
+			graph.parent)
+		if err != nil {
+			return nil, err
+		}
+
+		if castTo == nil {
+			return parentAssignment, nil
+		}
+
+		return &castAssignment{
+			Assignment: parentAssignment,
+			castTo:     castTo,
+		}, nil
+	}
+
+	if provider := graph.Provider(fieldID); provider != nil {
+		switch typedProvider := provider.(type) {
+		case *resolver.ModuleResolvedType:
+			providerName := ProviderName(typedProvider.Type, typedProvider.Qualifier)
+			return NewProviderAssignment(componentReceiverName, providerName, castTo), nil
+		case *resolver.InstanceResolvedType:
+			return &instanceAssignment{
+				componentReceiverName: componentReceiverName,
+				instanceName:          InstanceName(typedProvider),
+				castTo:                castTo,
+			}, nil
 		}
 
 		return nil, fmt.Errorf("Unknown provider type %+v", provider)
 	}
 
-	return NewFactoryAssignment(componentReceiverName, fieldName), nil
+	if qualifier != "" {
+		return nil, fmt.Errorf("No provider or binding found for %s", fieldID)
+	}
+
+	return NewFactoryAssignment(componentReceiverName, graph.FactoryName(fieldName)), nil
+}
+
+// assignmentForUnnamed returns an assignment for a type that is not named. The type
+// has to be provided, unless it is a slice or map that is collected from multibindings.
+func assignmentForUnnamed(
+	componentReceiverName string,
+	rawType types.Type,
+	qualifier string,
+	graph *Graph,
+) (Assignment, error) {
+	id := typeutil.QualifiedID(rawType, qualifier)
+	provider := graph.Provider(id)
+	if provider == nil {
+		switch rawType.(type) {
+		case *types.Slice, *types.Map:
+			if qualifier != "" {
+				return nil, fmt.Errorf("Multibinding %+v cannot be qualified", rawType)
+			}
+
+			return assignmentForMultibinding(componentReceiverName, rawType, graph)
+		case *types.Signature:
+			if qualifier != "" {
+				return nil, fmt.Errorf("No provider found for %s", id)
+			}
+
+			return assignmentForAssisted(componentReceiverName, rawType, graph)
+		default:
+			return nil, fmt.Errorf("No provider found for %s", id)
+		}
+	}
+
+	if !graph.isLocal(rawType, qualifier) {
+		return assignmentForUnnamed(componentReceiverName+"."+parentFieldName, rawType, qualifier, graph.parent)
+	}
+
+	switch typedProvider := provider.(type) {
+	case *resolver.ModuleResolvedType:
+		providerName := ProviderName(typedProvider.Type, typedProvider.Qualifier)
+		return NewProviderAssignment(componentReceiverName, providerName, nil), nil
+	case *resolver.InstanceResolvedType:
+		return &instanceAssignment{
+			componentReceiverName: componentReceiverName,
+			instanceName:          InstanceName(typedProvider),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("Unknown provider type %+v", provider)
+}
+
+// assignmentForAssisted returns an assignment of the given assisted factory. It calls
+// the factory of the struct generated by the component that creates the struct.
+func assignmentForAssisted(
+	componentReceiverName string,
+	factoryType types.Type,
+	graph *Graph,
+) (Assignment, error) {
+	name, err := graph.assistedStruct(factoryType)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == nil {
+		return nil, fmt.Errorf("No provider found for %s", typeutil.TypeID(factoryType))
+	}
+
+	if !graph.isLocal(name, "") {
+		return assignmentForAssisted(componentReceiverName+"."+parentFieldName, factoryType, graph.parent)
+	}
+
+	return &assistedAssignment{
+		componentReceiverName: componentReceiverName,
+		factoryName:           graph.FactoryName(name),
+		factoryType:           factoryType.(*types.Signature),
+	}, nil
+}
+
+// assignmentForMultibinding returns an assignment that calls the function collecting
+// the contributions to the given slice or map
+func assignmentForMultibinding(
+	componentReceiverName string,
+	multibindingType types.Type,
+	graph *Graph,
+) (Assignment, error) {
+	id, ok := typeutil.IDFromType(multibindingType)
+	if !ok {
+		return nil, fmt.Errorf("Field %+v is not a supported type", multibindingType)
+	}
+
+	if len(graph.contributions(id)) == 0 {
+		return nil, fmt.Errorf("Nothing is contributed to %+v", multibindingType)
+	}
+
+	if !graph.isLocalMultibinding(id) {
+		return assignmentForMultibinding(componentReceiverName+"."+parentFieldName, multibindingType, graph.parent)
+	}
+
+	return NewFactoryAssignment(componentReceiverName, graph.MultibindingName(id)), nil
 }
@@ -4,22 +4,17 @@ import (
 	"fmt"
 	"go/types"
 	"reflect"
-	"strconv"
 	"strings"
 
 	"github.com/dimes/dihedral/embeds"
-	"github.com/dimes/dihedral/resolver"
 	"github.com/dimes/dihedral/typeutil"
 	"github.com/pkg/errors"
 )
 
-const (
-	diTag   = "di"
-	skipTag = "-"
-)
-
 var (
-	injectType = reflect.TypeOf(embeds.Inject{})
+	injectType    = reflect.TypeOf(embeds.Inject{})
+	singletonType = reflect.TypeOf(embeds.Singleton{})
+	scopeType     = reflect.TypeOf(embeds.Scope{})
 )
 
 // GeneratedFactory contains information for generating a factory for
@@ -27,71 +22,137 @@ var (
 //
 // The generated code from this factory looks something like this:
 //
-// func TargetFactory(component *GeneratedComponent) *TargetType {
+// func TargetFactory(component *GeneratedComponent, resolution *inject.Cleanups) *TargetType {
 //     target := &TargetType{}
-//     targetType.ProvidedType = component.provides_ProvidedType()
-//     targetType.InjectableType = InjectableFactory(component)
+//     targetType.ProvidedType = component.provides_ProvidedType(resolution)
+//     targetType.InjectableType = InjectableFactory(component, resolution)
 //     return target
 // }
+//
+// Structs with a constructor function are created by calling the constructor with the
+// injected parameters instead:
+//
+// func TargetFactory(component *GeneratedComponent, resolution *inject.Cleanups) (*TargetType, error) {
+//     return NewTargetType(component.provides_ProvidedType(resolution), InjectableFactory(component, resolution))
+// }
+//
+// Fields with the `di:"assisted"` tag are not injected, but passed to the factory by the
+// assisted factory of the struct, see assistedAssignment:
+//
+// func TargetFactory(component *GeneratedComponent, resolution *inject.Cleanups, assisted0 UserID) (*TargetType, error) {
+//     target := &TargetType{}
+//     target.UserID = assisted0
+//     ...
+// }
+//
+// If a pointer to the struct has an `Init() error` or `Init(context.Context) error` method,
+// it is called once the struct is created, and an error it returns is returned by the factory.
+// The context is the one passed to the target method of the component.
+//
+// If a pointer to the struct has a `Close() error` method, it is added to the resolution,
+// so it is called if resolving whatever depends on the struct fails, or otherwise when
+// the component is closed. If it implements inject.Lifecycle, it is started and stopped
+// with the component.
+//
+// If the struct is marked with embeds.Singleton or with a scope, the constructed
+// instance is cached on the component and returned by subsequent calls to the factory. The
+// cache is guarded by a lock so the instance is constructed exactly once, even
+// when the factory is called concurrently. Failed constructions are not cached. Singletons
+// are created in a resolution of their own, which is moved to the component once the
+// singleton is created.
 type GeneratedFactory struct {
-	generatedComponentType     string
+	graph                      *Graph
 	generatedComponentReceiver string
 	targetName                 *types.Named
 	targetStruct               *types.Struct
-	assignments                map[string]Assignment
+	isSingleton                bool
+	constructor                *types.Func // Constructor of the struct, or nil
+	hasInit                    bool        // True if the struct has an Init hook
+	initHasContext             bool        // True if the Init hook takes a context.Context
+	hasClose                   bool        // True if the struct has a Close hook
+	hasLifecycle               bool        // True if the struct has Start and Stop hooks
+	fieldNames                 []string    // Names of the assigned fields, unless there is a constructor
+	assistedFields             []*typeutil.InjectedField
+	assignments                []Assignment
 	dependencies               []*injectionTarget
 }
 
 // NewGeneratedFactoryIfNeeded generates a factory for the given struct.
-// If a factory cannot be generated, e.g. if the struct is not injectable,
-// nil is returned
+// If a factory cannot be generated, e.g. if the struct is neither marked
+// injectable nor declared injectable by a binding module, nil is returned
 func NewGeneratedFactoryIfNeeded(
-	generatedComponentType string,
 	generatedComponentReceiver string,
 	targetName *types.Named,
 	targetStruct *types.Struct,
-	providers map[string]resolver.ResolvedType,
-	bindings map[string]*types.Named,
+	graph *Graph,
 ) (*GeneratedFactory, error) {
 	if targetStruct == nil {
 		return nil, nil
 	}
 
-	if !typeutil.HasFieldOfType(targetStruct, injectType) {
+	fields, injectable, err := graph.injectedFields(targetName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting fields of %+v", targetName)
+	}
+
+	if !injectable {
 		return nil, nil
 	}
 
-	assignments := make(map[string]Assignment)
-	dependencies := make([]*injectionTarget, 0)
-	for i := 0; i < targetStruct.NumFields(); i++ {
-		field := targetStruct.Field(i)
-		if !field.Exported() {
-			continue
+	var constructor *types.Func
+	if typeutil.HasFieldOfType(targetStruct, injectType) {
+		constructor, err = typeutil.Constructor(targetName, targetStruct, injectType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error getting constructor of %+v", targetName)
 		}
+	}
+
+	fieldNames := make([]string, 0)
+	assignments := make([]Assignment, 0)
+	dependencies := make([]*injectionTarget, 0)
 
-		tags := strings.Split(reflect.StructTag(targetStruct.Tag(i)).Get(diTag), ",")
-		if len(tags) > 0 && tags[0] == skipTag {
+	for _, field := range fields {
+		if field.IsAssisted {
 			continue
 		}
 
 		assignment, err := AssignmentForFieldType(
 			generatedComponentReceiver,
 			field.Type(),
-			providers,
-			bindings)
+			field.Qualifier,
+			graph)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Error generating bindings for %+v", targetStruct)
 		}
 
-		assignments[field.Name()] = assignment
-		dependencies = append(dependencies, newInjectionTarget(field.Type()))
+		fieldNames = append(fieldNames, field.Name())
+		assignments = append(assignments, assignment)
+		dependencies = append(dependencies, newInjectionTarget(field.Type(), field.Qualifier))
+	}
+
+	initHasContext := typeutil.HasContextHook(targetName, typeutil.InitHook)
+	isSingleton := typeutil.HasFieldOfType(targetStruct, singletonType) ||
+		typeutil.GetMarkedFieldType(targetStruct, scopeType) != nil
+
+	// Every call of an assisted factory creates a new struct from the passed fields
+	assistedFields := typeutil.AssistedFields(fields)
+	if isSingleton && len(assistedFields) > 0 {
+		return nil, fmt.Errorf("%+v has assisted fields and cannot be a singleton or scoped", targetName)
 	}
 
 	return &GeneratedFactory{
-		generatedComponentType:     generatedComponentType,
+		graph:                      graph,
 		generatedComponentReceiver: generatedComponentReceiver,
 		targetName:                 targetName,
 		targetStruct:               targetStruct,
+		isSingleton:                isSingleton,
+		constructor:                constructor,
+		hasInit:                    initHasContext || typeutil.HasHook(targetName, typeutil.InitHook),
+		initHasContext:             initHasContext,
+		hasClose:                   typeutil.HasHook(targetName, typeutil.CloseHook),
+		hasLifecycle:               typeutil.HasLifecycle(types.NewPointer(targetName)),
+		fieldNames:                 fieldNames,
+		assistedFields:             assistedFields,
 		assignments:                assignments,
 		dependencies:               dependencies,
 	}, nil
@@ -101,7 +162,6 @@ func NewGeneratedFactoryIfNeeded(
 // source should be treated as a separate source file in the generated
 // component package
 func (g *GeneratedFactory) ToSource(componentPackage string) string {
-	returnType := "target_pkg." + g.targetName.Obj().Name()
 	var builder strings.Builder
 	builder.WriteString("// Code generated by go generate; DO NOT EDIT.\n")
 	builder.WriteString("package " + componentPackage + "\n")
@@ -109,18 +169,22 @@ func (g *GeneratedFactory) ToSource(componentPackage string) string {
 	imports := map[string]string{
 		g.targetName.Obj().Pkg().Path(): "target_pkg",
 	}
+	addTypeImports(imports, g.targetName)
+	addImport(imports, injectPackage())
+	for _, field := range g.assistedFields {
+		addTypeImports(imports, field.Type())
+	}
 
 	for _, assignment := range g.assignments {
-		castTo := assignment.CastTo()
-		if castTo == nil {
-			continue
+		for _, pkg := range assignment.Packages() {
+			addImport(imports, pkg)
 		}
 
-		packagePath := castTo.Obj().Pkg().Path()
-		if importName := imports[packagePath]; importName == "" {
-			imports[packagePath] = "di_import_" + strconv.Itoa(len(imports)+1)
+		if castTo := assignment.CastTo(); castTo != nil {
+			addTypeImports(imports, castTo)
 		}
 	}
+	returnType := typeSource(g.targetName, imports)
 
 	builder.WriteString("import (\n")
 	for packagePath, importName := range imports {
@@ -129,37 +193,95 @@ func (g *GeneratedFactory) ToSource(componentPackage string) string {
 	builder.WriteString(")\n")
 
 	builder.WriteString(
-		"func " + FactoryName(g.targetName) +
-			"(" + g.generatedComponentReceiver + " *" + g.generatedComponentType +
-			") (*" + returnType + ", error) {\n")
-	builder.WriteString("\ttarget := &" + returnType + "{}\n")
+		"func " + g.graph.FactoryName(g.targetName) +
+			"(" + g.generatedComponentReceiver + " *" + g.graph.generatedTypeName +
+			", " + resolutionParam(imports))
+	for i, field := range g.assistedFields {
+		builder.WriteString(", " + assistedParamName(i) + " " + typeSource(field.Type(), imports))
+	}
+	builder.WriteString(") (*" + returnType + ", error) {\n")
 
-	paramCounter := 0
-	for name, assignment := range g.assignments {
-		paramName := fmt.Sprintf("param%d", paramCounter)
-		paramCounter++
+	singletonName := g.generatedComponentReceiver + "." + SingletonName(g.targetName)
+	if g.isSingleton {
+		writeSingletonStart(&builder, imports, g.generatedComponentReceiver, singletonName,
+			typeutil.IDFromNamed(g.targetName), "*"+returnType)
+	}
+
+	if g.constructor == nil {
+		builder.WriteString("\ttarget := &" + returnType + "{}\n")
+	}
 
-		builder.WriteString("\t" + paramName + ", err := " + assignment.GetSourceAssignment() + "\n")
+	for i, field := range g.assistedFields {
+		builder.WriteString("\ttarget." + field.Name() + " = " + assistedParamName(i) + "\n")
+	}
+
+	params := make([]string, 0)
+	for i, assignment := range g.assignments {
+		paramName := fmt.Sprintf("param%d", i)
+		builder.WriteString("\t" + paramName + ", err := " + assignment.GetSourceAssignment(imports) + "\n")
 		builder.WriteString("\tif err != nil {\n")
 		builder.WriteString("\t\tvar zeroValue *" + returnType + "\n")
 		builder.WriteString("\t\treturn zeroValue, err\n")
 		builder.WriteString("\t}\n")
 
 		sourceAssignment := paramName
-		castTo := assignment.CastTo()
-		if castTo != nil {
-			importName := imports[castTo.Obj().Pkg().Path()]
-			if importName != "" {
-				importName = importName + "."
-			}
-			sourceAssignment = "(" + importName + castTo.Obj().Name() + ")(" + sourceAssignment + ")"
+		if castTo := assignment.CastTo(); castTo != nil {
+			sourceAssignment = "(" + typeSource(castTo, imports) + ")(" + sourceAssignment + ")"
 		}
 
-		builder.WriteString("\ttarget." + name + " = " + sourceAssignment + "\n")
+		if g.constructor != nil {
+			params = append(params, sourceAssignment)
+			continue
+		}
+
+		builder.WriteString("\ttarget." + g.fieldNames[i] + " = " + sourceAssignment + "\n")
+	}
+
+	if g.constructor != nil {
+		constructorCall := "target_pkg." + g.constructor.Name() + "(" + strings.Join(params, ", ") + ")"
+		if g.constructor.Type().(*types.Signature).Results().Len() == 1 {
+			builder.WriteString("\ttarget := " + constructorCall + "\n")
+		} else {
+			builder.WriteString("\ttarget, err := " + constructorCall + "\n")
+			builder.WriteString("\tif err != nil {\n")
+			builder.WriteString("\t\tvar zeroValue *" + returnType + "\n")
+			builder.WriteString("\t\treturn zeroValue, err\n")
+			builder.WriteString("\t}\n")
+		}
+	}
+
+	if g.hasInit {
+		initArgs := ""
+		if g.initHasContext {
+			initArgs = resolutionContext
+		}
+
+		builder.WriteString("\tif err := target." + typeutil.InitHook + "(" + initArgs + "); err != nil {\n")
+		builder.WriteString("\t\tvar zeroValue *" + returnType + "\n")
+		builder.WriteString("\t\treturn zeroValue, err\n")
+		builder.WriteString("\t}\n")
+	}
+
+	// The target is closed if resolving whatever depends on it fails, or when the component is closed
+	if g.hasClose {
+		builder.WriteString("\t" + resolutionParamName + ".Add(target." + typeutil.CloseHook + ")\n")
+	}
+
+	if g.hasLifecycle {
+		builder.WriteString("\t" + resolutionParamName + ".AddLifecycle(target)\n")
 	}
 
 	builder.WriteString("\treturn target, nil\n")
+	if g.isSingleton {
+		writeSingletonEnd(&builder, singletonName, "*"+returnType)
+	}
 	builder.WriteString("}\n")
 
 	return builder.String()
 }
+
+// assistedParamName returns the name of the factory parameter for the assisted field
+// with the given index
+func assistedParamName(index int) string {
+	return fmt.Sprintf("assisted%d", index)
+}
@@ -9,78 +9,234 @@ import (
 	"strings"
 
 	"github.com/dimes/dihedral/embeds"
+	"github.com/dimes/dihedral/inject"
 	"github.com/dimes/dihedral/resolver"
 	"github.com/dimes/dihedral/structs"
 	"github.com/dimes/dihedral/typeutil"
 	"github.com/pkg/errors"
 )
 
+const (
+	parentFieldName   = "parent"
+	cleanupsFieldName = "cleanups"
+)
+
 var (
 	providedModuleType = reflect.TypeOf(embeds.ProvidedModule{})
+	cleanupsType       = reflect.TypeOf(inject.Cleanups{})
 )
 
 // GeneratedComponent is the resolve of GenerateComponent and contains helper methods
 // for converting this directly into source
 type GeneratedComponent struct {
+	graph                      *Graph
 	generatedTypeName          string
 	generatedComponentReceiver string
 	targetsAndAssignments      []*targetAndAssignment
 	factories                  []*GeneratedFactory
 	moduleProviders            []*GeneratedModuleProvider
+	multibindings              []*GeneratedMultibinding
+	subcomponents              []*generatedSubcomponent
+}
+
+type generatedSubcomponent struct {
+	subcomponent *resolver.Subcomponent
+	component    *GeneratedComponent
 }
 
 type injectionTarget struct {
-	Type types.Type
+	Type         types.Type
+	qualifier    string                 // Qualifier of the field or target method, or empty
+	contribution *resolver.Multibinding // Set for the provider of a multibinding contribution
 }
 
 type targetAndAssignment struct {
 	target     *resolver.InjectionTarget
 	assignment Assignment
+	members    *GeneratedFactory // Assigns the fields of members injection targets
 }
 
-func newInjectionTarget(targetType types.Type) *injectionTarget {
+func newInjectionTarget(targetType types.Type, qualifier string) *injectionTarget {
 	return &injectionTarget{
-		Type: targetType,
+		Type:      targetType,
+		qualifier: qualifier,
 	}
 }
 
-// NewGeneratedComponent generates the source for the given component
-func NewGeneratedComponent(
-	componentName string,
+// NewGeneratedComponent generates the source for the given resolved component
+func NewGeneratedComponent(result *resolver.ResolveResult) (*GeneratedComponent, error) {
+	return newGeneratedComponent(NewGraph(result), result.Targets, result.Subcomponents)
+}
+
+func newGeneratedComponent(
+	graph *Graph,
 	targets []*resolver.InjectionTarget,
-	providers map[string]resolver.ResolvedType,
-	bindings map[string]*types.Named,
+	subcomponents []*resolver.Subcomponent,
 ) (*GeneratedComponent, error) {
+	// Subcomponents are generated first, since the types they delegate to this
+	// component need to be generated here as well
+	generatedSubcomponents := make([]*generatedSubcomponent, 0)
+	for _, subcomponent := range subcomponents {
+		component, err := newGeneratedComponent(
+			graph.NewChild(subcomponent.Result),
+			subcomponent.Result.Targets,
+			subcomponent.Result.Subcomponents)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error generating subcomponent %s", subcomponent.MethodName)
+		}
+
+		generatedSubcomponents = append(generatedSubcomponents, &generatedSubcomponent{
+			subcomponent: subcomponent,
+			component:    component,
+		})
+	}
+
 	seenTargets := make(map[string]struct{})
+	generatedComponentReceiver := "d"
 
+	// Members injection targets are not created by the component, only their fields are
+	membersFactories := make(map[string]*GeneratedFactory)
 	injectionStack := make([]*injectionTarget, 0)
 	for _, target := range targets {
-		injectionStack = append(injectionStack, newInjectionTarget(target.Type))
+		if !target.IsMembers {
+			injectionStack = append(injectionStack, newInjectionTarget(target.Type, target.Qualifier))
+			continue
+		}
+
+		members, err := newMembersFactory(generatedComponentReceiver, target.Name, graph)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error getting members injection for %s", target.MethodName)
+		}
+
+		membersFactories[target.MethodName] = members
+		injectionStack = append(injectionStack, members.dependencies...)
 	}
+	injectionStack = append(injectionStack, graph.delegated...)
 
-	generatedTypeName := "Dihedral" + componentName
-	generatedComponentReceiver := "d"
+	generatedTypeName := graph.generatedTypeName
 	factories := make([]*GeneratedFactory, 0)
 	moduleProviderFuncs := make([]*GeneratedModuleProvider, 0)
+	multibindings := make([]*GeneratedMultibinding, 0)
 	for len(injectionStack) > 0 {
 		target := injectionStack[len(injectionStack)-1]
 		injectionStack = injectionStack[:len(injectionStack)-1]
 
+		if target.contribution != nil {
+			name := ContributionName(target.contribution.Provider)
+			if _, ok := seenTargets[name]; ok {
+				continue
+			}
+			seenTargets[name] = struct{}{}
+
+			moduleProviderFunc, err := newGeneratedProvider(
+				generatedComponentReceiver,
+				name,
+				target.contribution.Provider,
+				graph)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error getting provider for %+v", target.contribution.Provider)
+			}
+
+			moduleProviderFuncs = append(moduleProviderFuncs, moduleProviderFunc)
+			injectionStack = append(injectionStack, moduleProviderFunc.dependencies...)
+			continue
+		}
+
+		// Wrappers are assigned by the component itself, only the wrapped type needs
+		// a factory or provider. Optional types that are not bound are skipped.
+		targetType := target.Type
+		qualifier := target.qualifier
+		for {
+			unwrapped, wrapper := typeutil.UnwrapType(targetType)
+			if wrapper == nil {
+				break
+			}
+
+			if typeutil.IsOptional(wrapper) && !graph.isBound(unwrapped, qualifier) {
+				targetType = nil
+				break
+			}
+			targetType = unwrapped
+		}
+
+		// The context and the parameters of targets are passed to the component, and the
+		// component assigns itself, so they need no factory or provider
+		if targetType == nil || typeutil.IsContext(targetType) ||
+			(qualifier == "" && (graph.Param(targetType) != nil || graph.ComponentDepth(targetType) >= 0)) {
+			continue
+		}
+
+		// Functions that are not provided are assisted factories, which call the factory
+		// of the struct they create
+		assisted := false
+		if _, ok := targetType.(*types.Signature); ok &&
+			graph.Provider(typeutil.QualifiedID(targetType, qualifier)) == nil {
+			name, err := graph.assistedStruct(targetType)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error getting assisted factory %+v", targetType)
+			}
+
+			if name == nil || qualifier != "" {
+				return nil, fmt.Errorf("No provider found for %s", typeutil.QualifiedID(targetType, qualifier))
+			}
+
+			if !graph.isLocal(name, "") {
+				graph.parent.delegate(newInjectionTarget(targetType, ""))
+				continue
+			}
+
+			targetType = types.NewPointer(name)
+			assisted = true
+		}
+
+		switch targetType.(type) {
+		case *types.Slice, *types.Map:
+			// Slices and maps are collected from multibindings unless they are provided
+			if graph.Provider(typeutil.QualifiedID(targetType, qualifier)) != nil {
+				break
+			}
+
+			id, ok := typeutil.IDFromType(targetType)
+			if !ok || qualifier != "" {
+				return nil, fmt.Errorf("Target %+v is of an unsupported type", target)
+			}
+
+			if _, ok := seenTargets[id]; ok {
+				continue
+			}
+			seenTargets[id] = struct{}{}
+
+			if !graph.isLocalMultibinding(id) {
+				graph.parent.delegate(newInjectionTarget(targetType, ""))
+				continue
+			}
+
+			multibinding, err := NewGeneratedMultibinding(generatedComponentReceiver, targetType, graph)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error getting multibinding for %+v", targetType)
+			}
+
+			multibindings = append(multibindings, multibinding)
+			injectionStack = append(injectionStack, multibinding.dependencies...)
+			continue
+		}
+
 		var targetName *types.Named
 		var targetStruct *types.Struct
-		switch typedTarget := target.Type.(type) {
+		switch typedTarget := targetType.(type) {
 		case *types.Named:
-			targetID := typeutil.IDFromNamed(typedTarget)
-			if providers[targetID] != nil {
+			targetID := typeutil.QualifiedID(typedTarget, qualifier)
+			if graph.Provider(targetID) != nil {
 				targetName = typedTarget
 				// No target struct for providers
-			} else if boundType := bindings[targetID]; boundType != nil {
+			} else if boundType := graph.Binding(targetID); boundType != nil {
 				targetName = boundType
+				qualifier = ""
 
 				// Bound targets can either be generic names or structs. We only care
 				// if the type is a struct when we don't have a provider for the bound type
 				boundTargetID := typeutil.IDFromNamed(boundType)
-				if providers[boundTargetID] == nil {
+				if graph.Provider(boundTargetID) == nil {
 					boundType, ok := boundType.Underlying().(*types.Struct)
 					if !ok {
 						return nil, fmt.Errorf("%+v is not a struct and is not provided", boundType)
@@ -88,28 +244,53 @@ func NewGeneratedComponent(
 					targetStruct = boundType
 				}
 			} else {
-				return nil, fmt.Errorf("No type binding found for %+v", target)
+				return nil, fmt.Errorf("No type binding found for %s", typeutil.QualifiedID(typedTarget, qualifier))
 			}
 		case *types.Pointer:
-			targetName = typedTarget.Elem().(*types.Named)
-			targetStruct = targetName.Underlying().(*types.Struct)
-		default:
-			return nil, fmt.Errorf("Target %+v is of an unsupported type", target)
+			targetName, _ = typedTarget.Elem().(*types.Named)
+
+			// Qualified types are only created by providers
+			if targetName != nil && qualifier == "" {
+				targetStruct, _ = targetName.Underlying().(*types.Struct)
+			}
+		}
+
+		// Structs with assisted fields cannot be created without the assisted fields
+		if targetStruct != nil && !assisted {
+			fields, _, _ := graph.injectedFields(targetName)
+			if len(typeutil.AssistedFields(fields)) > 0 {
+				return nil, fmt.Errorf("%s has assisted fields and can only be created by its assisted factory",
+					typeutil.IDFromNamed(targetName))
+			}
+		}
+
+		// Types that are not named can only be provided
+		localType := types.Type(targetName)
+		if targetName == nil {
+			localType = targetType
 		}
 
-		targetID := typeutil.IDFromNamed(targetName)
+		targetID := typeutil.QualifiedID(localType, qualifier)
 		if _, ok := seenTargets[targetID]; ok {
 			continue
 		}
 		seenTargets[targetID] = struct{}{}
 
+		if !graph.isLocal(localType, qualifier) {
+			delegatedType := localType
+			if targetStruct != nil {
+				delegatedType = types.NewPointer(targetName)
+			}
+
+			graph.parent.delegate(newInjectionTarget(delegatedType, qualifier))
+			continue
+		}
+
 		factory, err := NewGeneratedFactoryIfNeeded(
-			generatedTypeName,
 			generatedComponentReceiver,
 			targetName,
 			targetStruct,
-			providers,
-			bindings)
+			graph)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Error getting factory for target %+v", targetStruct)
 		}
@@ -120,25 +301,25 @@ func NewGeneratedComponent(
 			continue
 		}
 
-		provider := providers[targetID]
+		provider := graph.Provider(targetID)
 		if provider == nil {
-			return nil, fmt.Errorf("Target %+v is not marked as injectable and has no provider", target)
+			return nil, fmt.Errorf("Target %s is not marked as injectable and has no provider", targetID)
 		}
 
 		switch typedProvider := provider.(type) {
 		case *resolver.ModuleResolvedType:
 			moduleProviderFunc, err := NewGeneratedProvider(
-				generatedTypeName,
 				generatedComponentReceiver,
 				typedProvider,
-				providers,
-				bindings)
+				graph)
 			if err != nil {
 				return nil, errors.Wrapf(err, "Error getting provider for %+v", provider)
 			}
 
 			moduleProviderFuncs = append(moduleProviderFuncs, moduleProviderFunc)
 			injectionStack = append(injectionStack, moduleProviderFunc.dependencies...)
+		case *resolver.InstanceResolvedType:
+			// Instances are fields of the component, so they need no provider
 		default:
 			return nil, fmt.Errorf("Provider %+v is of unknown type", provider)
 		}
@@ -146,11 +327,19 @@ func NewGeneratedComponent(
 
 	targetsAndAssignments := make([]*targetAndAssignment, 0)
 	for _, target := range targets {
+		if target.IsMembers {
+			targetsAndAssignments = append(targetsAndAssignments, &targetAndAssignment{
+				target:  target,
+				members: membersFactories[target.MethodName],
+			})
+			continue
+		}
+
 		assignment, err := AssignmentForFieldType(
 			generatedComponentReceiver,
 			target.Type,
-			providers,
-			bindings)
+			target.Qualifier,
+			graph)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Error getting toplevel target for %+v", target)
 		}
@@ -162,11 +351,14 @@ func NewGeneratedComponent(
 	}
 
 	return &GeneratedComponent{
+		graph:                      graph,
 		generatedTypeName:          generatedTypeName,
 		generatedComponentReceiver: generatedComponentReceiver,
 		targetsAndAssignments:      targetsAndAssignments,
 		factories:                  factories,
 		moduleProviders:            moduleProviderFuncs,
+		multibindings:              multibindings,
+		subcomponents:              generatedSubcomponents,
 	}, nil
 }
 
@@ -174,50 +366,103 @@ func NewGeneratedComponent(
 // source of this component
 func (g *GeneratedComponent) ToSource(componentPackage string) map[string]string {
 	imports := make(map[string]string)
-	seenModules := make(map[string]struct{})
-	moduleStructParams := make([]*structs.Struct, 0)
-	for _, provider := range g.moduleProviders {
-		packagePath := provider.resolvedType.Module.Name.Obj().Pkg().Path()
+	moduleStructParams := g.moduleStructs()
+	for _, module := range moduleStructParams {
+		packagePath := module.Name.Obj().Pkg().Path()
 		if _, ok := imports[packagePath]; !ok {
 			imports[packagePath] = "di_import_" + strconv.Itoa(len(imports)+1)
 		}
+	}
 
-		moduleID := typeutil.IDFromNamed(provider.resolvedType.Module.Name)
-		if _, ok := seenModules[moduleID]; ok {
-			continue
-		}
-		seenModules[moduleID] = struct{}{}
-
-		moduleStructParams = append(moduleStructParams, provider.resolvedType.Module)
+	instances := g.graph.instances()
+	for _, instance := range instances {
+		addTypeImports(imports, instance.Type)
 	}
 
 	for _, targetAssignment := range g.targetsAndAssignments {
 		target := targetAssignment.target
-		packagePath := target.Name.Obj().Pkg().Path()
-		if _, ok := imports[packagePath]; !ok {
-			imports[packagePath] = "di_import_" + strconv.Itoa(len(imports)+1)
+		assignments := []Assignment{targetAssignment.assignment}
+		if targetAssignment.members != nil {
+			assignments = targetAssignment.members.assignments
 		}
 
-		castTo := targetAssignment.assignment.CastTo()
-		if castTo != nil {
-			castToPackagePath := castTo.Obj().Pkg().Path()
-			if _, ok := imports[castToPackagePath]; !ok {
-				imports[castToPackagePath] = "di_import_" + strconv.Itoa(len(imports)+1)
+		packages := typePackages(target.Type)
+		for _, param := range target.Params {
+			if !typeutil.IsContext(param.Type()) {
+				packages = append(packages, typePackages(param.Type())...)
 			}
 		}
+
+		for _, assignment := range assignments {
+			packages = append(packages, assignment.Packages()...)
+		}
+
+		for _, pkg := range packages {
+			addImport(imports, pkg)
+		}
+
+		for _, assignment := range assignments {
+			if castTo := assignment.CastTo(); castTo != nil {
+				addTypeImports(imports, castTo)
+			}
+		}
+	}
+
+	singletonTypes := make([]types.Type, 0)
+	for _, provider := range g.moduleProviders {
+		if provider.isSingleton {
+			singletonTypes = append(singletonTypes, provider.resolvedType.Type)
+		}
 	}
 
+	for _, factory := range g.factories {
+		if factory.isSingleton {
+			singletonTypes = append(singletonTypes, factory.targetName)
+		}
+	}
+
+	for _, singletonType := range singletonTypes {
+		addTypeImports(imports, singletonType)
+	}
+
+	for _, subcomponent := range g.subcomponents {
+		subcomponentNames := []*types.Named{subcomponent.subcomponent.Result.TargetInterface}
+		for _, module := range subcomponent.subcomponent.Modules {
+			subcomponentNames = append(subcomponentNames, module.Name)
+		}
+
+		for _, module := range subcomponent.component.moduleStructs() {
+			subcomponentNames = append(subcomponentNames, module.Name)
+		}
+
+		for _, subcomponentName := range subcomponentNames {
+			packagePath := subcomponentName.Obj().Pkg().Path()
+			if _, ok := imports[packagePath]; !ok {
+				imports[packagePath] = "di_import_" + strconv.Itoa(len(imports)+1)
+			}
+		}
+	}
+
+	addImport(imports, injectPackage())
+
 	var builder strings.Builder
 	builder.WriteString("// Code generated by go generate; DO NOT EDIT.\n")
 	builder.WriteString("package " + componentPackage + "\n")
 
 	builder.WriteString("import (\n")
+	builder.WriteString("\t\"context\"\n")
 	for packagePath, importName := range imports {
 		builder.WriteString("\t" + importName + " \"" + packagePath + "\"\n")
 	}
 	builder.WriteString(")\n")
 
 	builder.WriteString("type " + g.generatedTypeName + " struct {\n")
+	if g.graph.parent != nil {
+		builder.WriteString("\t" + parentFieldName + " *" + g.graph.parent.generatedTypeName + "\n")
+	}
+	builder.WriteString(
+		"\t" + cleanupsFieldName + " " + imports[cleanupsType.PkgPath()] + "." + cleanupsType.Name() + "\n")
+
 	for _, module := range moduleStructParams {
 		moduleImportName := imports[module.Name.Obj().Pkg().Path()]
 		moduleTypeName := module.Name.Obj().Name()
@@ -225,60 +470,121 @@ func (g *GeneratedComponent) ToSource(componentPackage string) map[string]string
 		builder.WriteString(
 			"\t" + moduleVariableName + " *" + moduleImportName + "." + moduleTypeName + "\n")
 	}
-	builder.WriteString("}\n")
 
-	builder.WriteString("func New" + g.generatedTypeName + "(\n")
-	for _, module := range moduleStructParams {
-		if !typeutil.HasFieldOfType(module.Type, providedModuleType) {
+	for _, instance := range instances {
+		builder.WriteString("\t" + InstanceName(instance) + " " + typeSource(instance.Type, imports) + "\n")
+	}
+
+	for _, provider := range g.moduleProviders {
+		if !provider.isSingleton {
 			continue
 		}
 
-		moduleImportName := imports[module.Name.Obj().Pkg().Path()]
-		moduleTypeName := module.Name.Obj().Name()
-		moduleVariableName := SanitizeName(module.Name)
-		builder.WriteString(
-			"\t" + moduleVariableName + " *" + moduleImportName + "." + moduleTypeName + ",\n")
+		singletonType := typeSource(provider.resolvedType.Type, imports)
+		writeSingletonFields(&builder, imports, singletonPrefix+provider.name, singletonType)
 	}
-	builder.WriteString(") *" + g.generatedTypeName + " {\n")
-	builder.WriteString("\t return &" + g.generatedTypeName + "{\n")
-	for _, module := range moduleStructParams {
-		moduleImportName := imports[module.Name.Obj().Pkg().Path()]
-		moduleTypeName := module.Name.Obj().Name()
-		moduleVariableName := SanitizeName(module.Name)
 
-		provided := typeutil.HasFieldOfType(module.Type, providedModuleType)
-		if provided {
-			builder.WriteString(
-				"\t\t" + moduleVariableName + ": " + moduleVariableName + ",\n")
-		} else {
-			builder.WriteString(
-				"\t\t" + moduleVariableName + ": &" + moduleImportName + "." + moduleTypeName + "{},\n")
+	for _, factory := range g.factories {
+		if !factory.isSingleton {
+			continue
 		}
+
+		singletonType := "*" + typeSource(factory.targetName, imports)
+		writeSingletonFields(&builder, imports, SingletonName(factory.targetName), singletonType)
 	}
-	builder.WriteString("\t}\n")
 	builder.WriteString("}\n")
 
+	// Subcomponents are created by their parent component instead of a constructor
+	if g.graph.parent == nil {
+		g.writeConstructor(&builder, imports, moduleStructParams, instances)
+	}
+
+	for _, subcomponent := range g.subcomponents {
+		g.writeSubcomponentFactory(&builder, imports, subcomponent)
+	}
+
+	// Resources are cleaned up in the reverse order in which they were created
+	builder.WriteString(
+		"func (" + g.generatedComponentReceiver + " *" + g.generatedTypeName + ") Close() error {\n")
+	builder.WriteString("\treturn " + g.generatedComponentReceiver + "." + cleanupsFieldName + ".Close()\n")
+	builder.WriteString("}\n")
+
+	// Values are started in the order in which they were created and stopped in reverse
+	for _, method := range []string{typeutil.StartHook, typeutil.StopHook} {
+		builder.WriteString(
+			"func (" + g.generatedComponentReceiver + " *" + g.generatedTypeName + ") " +
+				method + "(ctx context.Context) error {\n")
+		builder.WriteString(
+			"\treturn " + g.generatedComponentReceiver + "." + cleanupsFieldName + "." + method + "(ctx)\n")
+		builder.WriteString("}\n")
+	}
+
 	for _, targetAssignment := range g.targetsAndAssignments {
 		target := targetAssignment.target
-		importName := imports[target.Name.Obj().Pkg().Path()]
-		targetTypeName := target.Name.Obj().Name()
-		returnType := importName + "." + targetTypeName
-		if target.IsPointer {
-			returnType = "*" + returnType
+		if targetAssignment.members != nil {
+			g.writeMembersInjection(&builder, imports, target, targetAssignment.members)
+			continue
 		}
+
+		returnType := typeSource(target.Type, imports)
 		assignment := targetAssignment.assignment
+		// Targets that take no context are created with the background context. The other
+		// parameters are carried by the context of the resolution.
+		context := "context.Background()"
+		if target.HasContext {
+			context = "ctx"
+		}
+
+		params := make([]string, 0, len(target.Params))
+		for i, param := range target.Params {
+			if typeutil.IsContext(param.Type()) {
+				params = append(params, "ctx context.Context")
+				continue
+			}
+
+			paramName := fmt.Sprintf("param%d", i)
+			params = append(params, paramName+" "+typeSource(param.Type(), imports))
+			context = imports[cleanupsType.PkgPath()] + ".WithParam(" + context + ", " +
+				strconv.Quote(typeutil.TypeID(param.Type())) + ", " + paramName + ")"
+		}
+
 		builder.WriteString(
 			"func (" + g.generatedComponentReceiver +
-				" *" + g.generatedTypeName + ") " + target.MethodName + "() (" +
+				" *" + g.generatedTypeName + ") " + target.MethodName + "(" + strings.Join(params, ", ") + ") (" +
 				returnType)
+		if target.HasCleanup {
+			builder.WriteString(", func() error")
+		}
 		if target.HasError {
 			builder.WriteString(", error")
 		}
 		builder.WriteString(") {\n")
 
-		builder.WriteString("\tobj, err := " + assignment.GetSourceAssignment() + "\n")
+		// Everything created for the target is cleaned up if creating the target fails.
+		// Targets that return a cleanup function leave the rest to the caller.
+		if target.HasCleanup {
+			writeResolveWithCleanupStart(&builder, imports, g.generatedComponentReceiver, "obj", "cleanup",
+				returnType, context)
+		} else {
+			writeResolveStart(&builder, imports, g.generatedComponentReceiver, "obj", returnType, context)
+		}
+		if castTo := assignment.CastTo(); castTo != nil {
+			builder.WriteString("\tvalue, err := " + assignment.GetSourceAssignment(imports) + "\n")
+			builder.WriteString("\tif err != nil {\n")
+			builder.WriteString("\t\tvar zeroValue " + returnType + "\n")
+			builder.WriteString("\t\treturn zeroValue, err\n")
+			builder.WriteString("\t}\n")
+			builder.WriteString("\treturn (" + typeSource(castTo, imports) + ")(value), nil\n")
+		} else {
+			builder.WriteString("\treturn " + assignment.GetSourceAssignment(imports) + "\n")
+		}
+		builder.WriteString("\t})\n")
+
 		builder.WriteString("\tif err != nil {\n")
-		if target.HasError {
+		if target.HasError && target.HasCleanup {
+			builder.WriteString("\t\tvar zeroValue " + returnType + "\n")
+			builder.WriteString("\t\treturn zeroValue, nil, err\n")
+		} else if target.HasError {
 			builder.WriteString("\t\tvar zeroValue " + returnType + "\n")
 			builder.WriteString("\t\treturn zeroValue, err\n")
 		} else {
@@ -286,17 +592,10 @@ func (g *GeneratedComponent) ToSource(componentPackage string) map[string]string
 		}
 		builder.WriteString("\t}\n")
 
-		castTo := assignment.CastTo()
-		returnObj := "obj"
-		if castTo != nil {
-			importName := imports[castTo.Obj().Pkg().Path()]
-			if importName != "" {
-				importName = importName + "."
-			}
-			returnObj = "(" + importName + castTo.Obj().Name() + ")(" + returnObj + ")"
+		builder.WriteString("\treturn obj")
+		if target.HasCleanup {
+			builder.WriteString(", cleanup")
 		}
-
-		builder.WriteString("\treturn " + returnObj)
 		if target.HasError {
 			builder.WriteString(", nil")
 		}
@@ -305,17 +604,142 @@ func (g *GeneratedComponent) ToSource(componentPackage string) map[string]string
 		builder.WriteString("}\n")
 	}
 
+	namePrefix := g.graph.namePrefix
 	output := map[string]string{
-		"component": builder.String(),
+		namePrefix + "component": builder.String(),
 	}
 
 	for _, factory := range g.factories {
-		output[SanitizeName(factory.targetName)+"_Factory"] = factory.ToSource(componentPackage)
+		output[namePrefix+SanitizeName(factory.targetName)+"_Factory"] = factory.ToSource(componentPackage)
 	}
 
 	for _, provider := range g.moduleProviders {
-		output[SanitizeName(provider.resolvedType.Name)+"_Provider"] = provider.ToSource(componentPackage)
+		output[namePrefix+provider.name+"_Provider"] = provider.ToSource(componentPackage)
+	}
+
+	for _, multibinding := range g.multibindings {
+		output[namePrefix+sanitizeID(multibinding.id)+"_Multibinding"] = multibinding.ToSource(componentPackage)
+	}
+
+	for _, subcomponent := range g.subcomponents {
+		for name, source := range subcomponent.component.ToSource(componentPackage) {
+			output[name] = source
+		}
 	}
 
 	return output
 }
+
+// moduleStructs returns the modules that are used by the providers of this component
+func (g *GeneratedComponent) moduleStructs() []*structs.Struct {
+	seenModules := make(map[string]struct{})
+	moduleStructParams := make([]*structs.Struct, 0)
+	for _, provider := range g.moduleProviders {
+		// Constructor functions do not need a module
+		if provider.resolvedType.Module == nil {
+			continue
+		}
+
+		moduleID := typeutil.IDFromNamed(provider.resolvedType.Module.Name)
+		if _, ok := seenModules[moduleID]; ok {
+			continue
+		}
+		seenModules[moduleID] = struct{}{}
+
+		moduleStructParams = append(moduleStructParams, provider.resolvedType.Module)
+	}
+
+	return moduleStructParams
+}
+
+// writeConstructor writes the exported function that creates a top-level component. It
+// takes the provided modules, followed by the instances declared by the definition.
+func (g *GeneratedComponent) writeConstructor(
+	builder *strings.Builder,
+	imports map[string]string,
+	moduleStructParams []*structs.Struct,
+	instances []*resolver.InstanceResolvedType,
+) {
+	builder.WriteString("func New" + g.generatedTypeName + "(\n")
+	for _, module := range moduleStructParams {
+		if !typeutil.HasFieldOfType(module.Type, providedModuleType) {
+			continue
+		}
+
+		moduleImportName := imports[module.Name.Obj().Pkg().Path()]
+		moduleTypeName := module.Name.Obj().Name()
+		moduleVariableName := SanitizeName(module.Name)
+		builder.WriteString(
+			"\t" + moduleVariableName + " *" + moduleImportName + "." + moduleTypeName + ",\n")
+	}
+	for _, instance := range instances {
+		builder.WriteString("\t" + InstanceName(instance) + " " + typeSource(instance.Type, imports) + ",\n")
+	}
+	builder.WriteString(") *" + g.generatedTypeName + " {\n")
+	builder.WriteString("\t return &" + g.generatedTypeName + "{\n")
+	for _, module := range moduleStructParams {
+		moduleImportName := imports[module.Name.Obj().Pkg().Path()]
+		moduleTypeName := module.Name.Obj().Name()
+		moduleVariableName := SanitizeName(module.Name)
+
+		provided := typeutil.HasFieldOfType(module.Type, providedModuleType)
+		if provided {
+			builder.WriteString(
+				"\t\t" + moduleVariableName + ": " + moduleVariableName + ",\n")
+		} else {
+			builder.WriteString(
+				"\t\t" + moduleVariableName + ": &" + moduleImportName + "." + moduleTypeName + "{},\n")
+		}
+	}
+	for _, instance := range instances {
+		builder.WriteString("\t\t" + InstanceName(instance) + ": " + InstanceName(instance) + ",\n")
+	}
+	builder.WriteString("\t}\n")
+	builder.WriteString("}\n")
+}
+
+// writeSubcomponentFactory writes the method on this component that creates the
+// given subcomponent. Provided modules are taken from the method parameters and
+// all other modules are created by the method.
+func (g *GeneratedComponent) writeSubcomponentFactory(
+	builder *strings.Builder,
+	imports map[string]string,
+	subcomponent *generatedSubcomponent,
+) {
+	componentInterface := subcomponent.subcomponent.Result.TargetInterface
+	builder.WriteString(
+		"func (" + g.generatedComponentReceiver + " *" + g.generatedTypeName + ") " +
+			subcomponent.subcomponent.MethodName + "(\n")
+	for _, module := range subcomponent.subcomponent.Modules {
+		moduleImportName := imports[module.Name.Obj().Pkg().Path()]
+		builder.WriteString(
+			"\t" + SanitizeName(module.Name) + " *" + moduleImportName + "." + module.Name.Obj().Name() + ",\n")
+	}
+	builder.WriteString(
+		") " + imports[componentInterface.Obj().Pkg().Path()] + "." + componentInterface.Obj().Name() + " {\n")
+
+	builder.WriteString("\treturn &" + subcomponent.component.generatedTypeName + "{\n")
+	builder.WriteString("\t\t" + parentFieldName + ": " + g.generatedComponentReceiver + ",\n")
+	for _, module := range subcomponent.component.moduleStructs() {
+		moduleImportName := imports[module.Name.Obj().Pkg().Path()]
+		moduleVariableName := SanitizeName(module.Name)
+		if typeutil.HasFieldOfType(module.Type, providedModuleType) {
+			builder.WriteString("\t\t" + moduleVariableName + ": " + moduleVariableName + ",\n")
+		} else {
+			builder.WriteString(
+				"\t\t" + moduleVariableName + ": &" + moduleImportName + "." + module.Name.Obj().Name() + "{},\n")
+		}
+	}
+	builder.WriteString("\t}\n")
+	builder.WriteString("}\n")
+}
+
+func writeSingletonFields(
+	builder *strings.Builder,
+	imports map[string]string,
+	singletonName string,
+	singletonType string,
+) {
+	builder.WriteString("\t" + singletonName + " " + singletonType + "\n")
+	builder.WriteString("\t" + singletonName + "_lock " + imports[cleanupsType.PkgPath()] + ".SingletonLock\n")
+}
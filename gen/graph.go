@@ -0,0 +1,414 @@
+package gen
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+
+	"github.com/dimes/dihedral/resolver"
+	"github.com/dimes/dihedral/typeutil"
+)
+
+// Graph contains the providers and bindings that are available to a generated
+// component. The graph of a subcomponent also contains everything available
+// in the graph of its parent.
+type Graph struct {
+	parent            *Graph
+	generatedTypeName string
+	component         *types.Named // Target interface of the component
+	namePrefix        string
+	scope             *types.Named
+	providers         map[string]resolver.ResolvedType
+	bindings          map[string]*types.Named
+	multibindings     map[string][]*resolver.Multibinding
+	injectables       map[string]*resolver.Injectable
+	params            map[string]types.Type
+	local             map[string]bool
+	visiting          map[string]int // Depth of the types whose locality is being checked
+	guarded           int            // Lowest depth in visiting that was reached again
+	delegated         []*injectionTarget
+}
+
+// contribution is a multibinding contribution together with the graph of the component
+// whose modules contribute it
+type contribution struct {
+	graph        *Graph
+	multibinding *resolver.Multibinding
+}
+
+// NewGraph returns the graph for a top-level component
+func NewGraph(result *resolver.ResolveResult) *Graph {
+	return &Graph{
+		generatedTypeName: "Dihedral" + result.TargetInterfaceName,
+		component:         result.TargetInterface,
+		scope:             result.Scope,
+		providers:         result.Providers,
+		bindings:          result.Bindings,
+		multibindings:     result.Multibindings,
+		injectables:       result.Injectables,
+		params:            result.Params,
+		local:             make(map[string]bool),
+		visiting:          make(map[string]int),
+	}
+}
+
+// NewChild returns the graph for a subcomponent of this graph
+func (g *Graph) NewChild(result *resolver.ResolveResult) *Graph {
+	child := NewGraph(result)
+	child.parent = g
+	child.namePrefix = child.generatedTypeName + "_"
+	return child
+}
+
+// Provider returns the provider for the given ID, or nil if there is none
+func (g *Graph) Provider(id string) resolver.ResolvedType {
+	for graph := g; graph != nil; graph = graph.parent {
+		if provider := graph.providers[id]; provider != nil {
+			return provider
+		}
+	}
+
+	return nil
+}
+
+// instances returns the instances passed to the constructor of the component of this graph,
+// in the order in which they are declared
+func (g *Graph) instances() []*resolver.InstanceResolvedType {
+	instances := make([]*resolver.InstanceResolvedType, 0)
+	for _, provider := range g.providers {
+		if instance, ok := provider.(*resolver.InstanceResolvedType); ok {
+			instances = append(instances, instance)
+		}
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Index < instances[j].Index
+	})
+	return instances
+}
+
+// Binding returns the type bound to the given ID, or nil if there is none
+func (g *Graph) Binding(id string) *types.Named {
+	for graph := g; graph != nil; graph = graph.parent {
+		if binding := graph.bindings[id]; binding != nil {
+			return binding
+		}
+	}
+
+	return nil
+}
+
+// ComponentDepth returns the number of parents between this graph and the graph of the
+// component whose target interface is the given type, or -1 if there is no such component
+func (g *Graph) ComponentDepth(rawType types.Type) int {
+	depth := 0
+	for graph := g; graph != nil; graph = graph.parent {
+		if graph.component != nil && types.Identical(graph.component, rawType) {
+			return depth
+		}
+		depth++
+	}
+
+	return -1
+}
+
+// Param returns the type of the parameters of target methods that are injected in place of
+// the given (unqualified) type, or nil if the type is not a parameter
+func (g *Graph) Param(rawType types.Type) types.Type {
+	for graph := g; graph != nil; graph = graph.parent {
+		if paramType, ok := graph.params[typeutil.TypeID(rawType)]; ok {
+			return paramType
+		}
+	}
+
+	return nil
+}
+
+// injectedFields returns the fields, or constructor parameters, that are injected into the
+// given struct by its factory, and false if the struct is neither marked with embeds.Inject nor declared
+// injectable by a binding module of this graph or its parents
+func (g *Graph) injectedFields(name *types.Named) ([]*typeutil.InjectedField, bool, error) {
+	for graph := g; graph != nil; graph = graph.parent {
+		if injectable := graph.injectables[typeutil.IDFromNamed(name)]; injectable != nil {
+			return injectable.Fields, true, nil
+		}
+	}
+
+	targetStruct, ok := name.Underlying().(*types.Struct)
+	if !ok || !typeutil.HasFieldOfType(targetStruct, injectType) {
+		return nil, false, nil
+	}
+
+	// Structs with a constructor are created from the parameters of the constructor
+	constructor, err := typeutil.Constructor(name, targetStruct, injectType)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if constructor != nil {
+		return typeutil.ConstructorParams(constructor), true, nil
+	}
+
+	fields, err := typeutil.InjectedFields(targetStruct)
+	return fields, true, err
+}
+
+// assistedStruct returns the struct created by the given assisted factory, or nil if
+// the type is not an assisted factory of an injectable struct. The parameters of the
+// factory have to match the assisted fields of the struct.
+func (g *Graph) assistedStruct(factoryType types.Type) (*types.Named, error) {
+	name := typeutil.AssistedStruct(factoryType)
+	if name == nil {
+		return nil, nil
+	}
+
+	fields, injectable, err := g.injectedFields(name)
+	if err != nil || !injectable {
+		return nil, err
+	}
+
+	assisted := typeutil.AssistedFields(fields)
+	params := factoryType.(*types.Signature).Params()
+	if len(assisted) == 0 || params.Len() != len(assisted) {
+		return nil, fmt.Errorf("Expected %+v to take the %d assisted fields of %s",
+			factoryType, len(assisted), typeutil.IDFromNamed(name))
+	}
+
+	for i, field := range assisted {
+		if !types.Identical(params.At(i).Type(), field.Type()) {
+			return nil, fmt.Errorf("Expected parameter %d of %+v to have the type of the assisted field %s",
+				i, factoryType, field.Name())
+		}
+	}
+
+	return name, nil
+}
+
+// contributions returns the contributions to the slice or map with the given ID from this
+// graph and its parents. Contributions of parents come first.
+func (g *Graph) contributions(id string) []*contribution {
+	var contributions []*contribution
+	if g.parent != nil {
+		contributions = g.parent.contributions(id)
+	}
+
+	for _, multibinding := range g.multibindings[id] {
+		contributions = append(contributions, &contribution{
+			graph:        g,
+			multibinding: multibinding,
+		})
+	}
+
+	return contributions
+}
+
+// MultibindingName returns the name of the function generated in this graph that
+// collects the contributions to the slice or map with the given ID
+func (g *Graph) MultibindingName(id string) string {
+	return "multibinds_" + g.namePrefix + sanitizeID(id)
+}
+
+// FactoryName returns the name of the factory function generated in this graph
+// for the given name. Subcomponents prefix their factories with the generated type
+// so that they do not clash with the factories of other components in the package.
+func (g *Graph) FactoryName(typeName *types.Named) string {
+	if g.parent == nil {
+		return FactoryName(typeName)
+	}
+
+	return "factory_" + g.namePrefix + SanitizeName(typeName)
+}
+
+// isLocal returns true if the given (already bound) type with the given qualifier has to
+// be created by this component rather than by one of its parents. This is the case if the
+// type, or anything it transitively depends on, is provided by the modules of this component.
+// Scoped types are always created by the component with the same scope. The type is either
+// named or provided.
+func (g *Graph) isLocal(rawType types.Type, qualifier string) bool {
+	if g.parent == nil {
+		return true
+	}
+
+	id := typeutil.QualifiedID(rawType, qualifier)
+	if local, ok := g.local[id]; ok {
+		return local
+	}
+
+	// Parameters are only passed to the targets of the component that takes them
+	if _, ok := g.params[id]; ok && qualifier == "" {
+		return true
+	}
+
+	// Every component assigns itself in place of its target interface
+	if g.ComponentDepth(rawType) == 0 && qualifier == "" {
+		return true
+	}
+
+	// Types reached again through a Lazy or Provider are not local because of themselves.
+	// Results that rely on such a guard are only cached once the guarded type is done.
+	if depth, ok := g.visiting[id]; ok {
+		g.guarded = min(g.guarded, depth)
+		return false
+	}
+
+	depth := len(g.visiting)
+	outerGuarded := g.guarded
+	g.visiting[id] = depth
+	g.guarded = depth
+
+	local := g.checkLocal(id, rawType, qualifier)
+	delete(g.visiting, id)
+
+	if local || g.guarded >= depth {
+		g.local[id] = local
+	}
+
+	g.guarded = min(outerGuarded, g.guarded)
+	return local
+}
+
+// checkLocal returns true if the given type, or anything it transitively depends on, is
+// provided by the modules of this component. See isLocal.
+func (g *Graph) checkLocal(id string, rawType types.Type, qualifier string) bool {
+	if g.Provider(id) != nil {
+		_, local := g.providers[id]
+		return local
+	}
+
+	name := namedFromType(rawType)
+	if name == nil || qualifier != "" {
+		return false
+	}
+
+	// Invalid fields are reported when the factory is generated
+	fields, injectable, _ := g.injectedFields(name)
+	if targetStruct, ok := name.Underlying().(*types.Struct); ok && injectable {
+		if scope := typeutil.GetMarkedFieldType(targetStruct, scopeType); scope != nil {
+			return g.scope != nil && typeutil.IDFromNamed(g.scope) == typeutil.IDFromNamed(scope)
+		}
+	}
+
+	// Parents cannot create structs that are declared injectable by this component
+	_, local := g.injectables[typeutil.IDFromNamed(name)]
+	for i := 0; i < len(fields) && !local; i++ {
+		local = !fields[i].IsAssisted && g.isLocalType(fields[i].Type(), fields[i].Qualifier)
+	}
+
+	return local
+}
+
+// isLocalType returns true if the given (unbound) type of a field or parameter has
+// to be created by this component rather than by one of its parents
+func (g *Graph) isLocalType(rawType types.Type, qualifier string) bool {
+	rawType = typeutil.UnwrapAll(rawType)
+	if qualifier == "" && g.Param(rawType) != nil {
+		return g.isLocal(rawType, qualifier)
+	}
+
+	name := namedFromType(rawType)
+	if name == nil {
+		if g.Provider(typeutil.QualifiedID(rawType, qualifier)) != nil {
+			return g.isLocal(rawType, qualifier)
+		}
+
+		switch rawType.(type) {
+		case *types.Slice, *types.Map:
+			id, ok := typeutil.IDFromType(rawType)
+			return ok && g.isLocalMultibinding(id)
+		case *types.Signature:
+			// Assisted factories are created by the component that creates the struct
+			name := typeutil.AssistedStruct(rawType)
+			return name != nil && g.isLocal(name, "")
+		default:
+			return false
+		}
+	}
+
+	if binding := g.Binding(typeutil.QualifiedID(name, qualifier)); binding != nil {
+		name = binding
+		qualifier = ""
+	}
+
+	return g.isLocal(name, qualifier)
+}
+
+// isBound returns true if the given type of a field or parameter with the given qualifier
+// can be created by this component or one of its parents
+func (g *Graph) isBound(rawType types.Type, qualifier string) bool {
+	rawType = typeutil.UnwrapAll(rawType)
+	if qualifier == "" && g.Param(rawType) != nil {
+		return true
+	}
+
+	if namedFromType(rawType) == nil && g.Provider(typeutil.QualifiedID(rawType, qualifier)) != nil {
+		return true
+	}
+
+	switch typed := rawType.(type) {
+	case *types.Slice, *types.Map:
+		id, ok := typeutil.IDFromType(rawType)
+		return ok && qualifier == "" && len(g.contributions(id)) > 0
+	case *types.Signature:
+		name, err := g.assistedStruct(rawType)
+		return err == nil && name != nil && qualifier == ""
+	case *types.Named:
+		id := typeutil.QualifiedID(typed, qualifier)
+		return g.Provider(id) != nil || g.Binding(id) != nil
+	case *types.Pointer:
+		name, ok := typed.Elem().(*types.Named)
+		if !ok {
+			return false
+		}
+
+		id := typeutil.QualifiedID(name, qualifier)
+		if g.Provider(id) != nil || g.Binding(id) != nil {
+			return true
+		}
+
+		// Qualified types are never created by a factory
+		_, injectable, _ := g.injectedFields(name)
+		return injectable && qualifier == ""
+	default:
+		return false
+	}
+}
+
+// isLocalMultibinding returns true if the slice or map with the given ID has to be created
+// by this component. This is the case if the modules of this component contribute to
+// it, or if a contribution has to be created by this component.
+func (g *Graph) isLocalMultibinding(id string) bool {
+	if g.parent == nil {
+		return true
+	}
+
+	for _, contribution := range g.contributions(id) {
+		if contribution.graph == g {
+			return true
+		}
+
+		if binding := contribution.multibinding.Binding; binding != nil && g.isLocalType(binding, "") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// delegate records that the given target is created by this graph on behalf of
+// one of its subcomponents
+func (g *Graph) delegate(target *injectionTarget) {
+	g.delegated = append(g.delegated, target)
+}
+
+// namedFromType returns the named type of a named type or a pointer to a named type
+func namedFromType(rawType types.Type) *types.Named {
+	switch typed := rawType.(type) {
+	case *types.Named:
+		return typed
+	case *types.Pointer:
+		named, _ := typed.Elem().(*types.Named)
+		return named
+	default:
+		return nil
+	}
+}
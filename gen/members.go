@@ -0,0 +1,93 @@
+package gen
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/dimes/dihedral/resolver"
+	"github.com/dimes/dihedral/typeutil"
+)
+
+// newMembersFactory returns the factory whose assignments inject the fields of the given
+// struct into an instance that was created outside of the component. Since the instance
+// already exists, the struct cannot have a constructor or be cached by the component.
+func newMembersFactory(
+	generatedComponentReceiver string,
+	targetName *types.Named,
+	graph *Graph,
+) (*GeneratedFactory, error) {
+	targetStruct, _ := targetName.Underlying().(*types.Struct)
+	factory, err := NewGeneratedFactoryIfNeeded(generatedComponentReceiver, targetName, targetStruct, graph)
+	if err != nil {
+		return nil, err
+	}
+
+	targetID := typeutil.IDFromNamed(targetName)
+	if factory == nil {
+		return nil, fmt.Errorf("%s is not marked as injectable", targetID)
+	}
+
+	if factory.constructor != nil {
+		return nil, fmt.Errorf("%s is created by a constructor, so its fields cannot be injected", targetID)
+	}
+
+	if factory.isSingleton {
+		return nil, fmt.Errorf("%s is a singleton or scoped, so its fields cannot be injected", targetID)
+	}
+
+	return factory, nil
+}
+
+// writeMembersInjection writes a target method that injects the fields of the struct passed
+// to it. The fields are only assigned once all of them are resolved, so the struct is left
+// untouched if resolving one of them fails. Hooks of the struct are not called, since the
+// struct is not created by the component.
+func (g *GeneratedComponent) writeMembersInjection(
+	builder *strings.Builder,
+	imports map[string]string,
+	target *resolver.InjectionTarget,
+	members *GeneratedFactory,
+) {
+	targetType := typeSource(target.Type, imports)
+	builder.WriteString(
+		"func (" + g.generatedComponentReceiver + " *" + g.generatedTypeName + ") " + target.MethodName +
+			"(target " + targetType + ")")
+	if target.HasError {
+		builder.WriteString(" error")
+	}
+	builder.WriteString(" {\n")
+
+	writeResolveStart(builder, imports, g.generatedComponentReceiver, "_", targetType, "context.Background()")
+	fields := make([]string, 0, len(members.assignments))
+	for i, assignment := range members.assignments {
+		paramName := fmt.Sprintf("param%d", i)
+		builder.WriteString("\t" + paramName + ", err := " + assignment.GetSourceAssignment(imports) + "\n")
+		builder.WriteString("\tif err != nil {\n")
+		builder.WriteString("\t\treturn nil, err\n")
+		builder.WriteString("\t}\n")
+
+		sourceAssignment := paramName
+		if castTo := assignment.CastTo(); castTo != nil {
+			sourceAssignment = "(" + typeSource(castTo, imports) + ")(" + sourceAssignment + ")"
+		}
+
+		fields = append(fields, "\ttarget."+members.fieldNames[i]+" = "+sourceAssignment+"\n")
+	}
+
+	for _, field := range fields {
+		builder.WriteString(field)
+	}
+	builder.WriteString("\treturn target, nil\n")
+	builder.WriteString("\t})\n")
+
+	if target.HasError {
+		builder.WriteString("\treturn err\n")
+	} else {
+		builder.WriteString("\tif err != nil {\n")
+		builder.WriteString("\t\tpanic(err)\n")
+		builder.WriteString("\t}\n")
+	}
+
+	builder.WriteString("}\n")
+}
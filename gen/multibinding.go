@@ -0,0 +1,160 @@
+package gen
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/dimes/dihedral/resolver"
+	"github.com/dimes/dihedral/typeutil"
+	"github.com/pkg/errors"
+)
+
+// GeneratedMultibinding collects all contributions to a slice or a map
+//
+// The generated code looks something like this:
+//
+// func multibinds_slice_Handler(component *GeneratedComponent, resolution *inject.Cleanups) ([]Handler, error) {
+//     elements := make([]Handler, 0, 2)
+//     element0, err := component.provides_HandlerModule_ProvidesHealthHandler(resolution)
+//     if err != nil {
+//         return nil, err
+//     }
+//     elements = append(elements, (Handler)(element0))
+//     element1, err := factory_StatusHandler(component, resolution)
+//     ...
+//     return elements, nil
+// }
+//
+// Contributions to maps are assigned to their key instead of being appended. Contributions
+// are added in the order they are declared in, starting with the contributions of the
+// parent components.
+type GeneratedMultibinding struct {
+	graph                      *Graph
+	generatedComponentReceiver string
+	id                         string
+	multibindingType           types.Type
+	multibindings              []*resolver.Multibinding
+	assignments                []Assignment
+	dependencies               []*injectionTarget
+}
+
+// NewGeneratedMultibinding generates the function collecting the contributions to
+// the given slice or map. Contributions provided by the modules of a parent component
+// are delegated to that component.
+func NewGeneratedMultibinding(
+	generatedComponentReceiver string,
+	multibindingType types.Type,
+	graph *Graph,
+) (*GeneratedMultibinding, error) {
+	id, ok := typeutil.IDFromType(multibindingType)
+	if !ok {
+		return nil, fmt.Errorf("%+v is not a supported multibinding", multibindingType)
+	}
+
+	multibindings := make([]*resolver.Multibinding, 0)
+	assignments := make([]Assignment, 0)
+	dependencies := make([]*injectionTarget, 0)
+	for _, contribution := range graph.contributions(id) {
+		multibinding := contribution.multibinding
+		multibindings = append(multibindings, multibinding)
+		if multibinding.Provider == nil {
+			assignment, err := AssignmentForFieldType(generatedComponentReceiver, multibinding.Binding, "", graph)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error generating contribution to %+v", multibindingType)
+			}
+
+			assignments = append(assignments, assignment)
+			dependencies = append(dependencies, newInjectionTarget(multibinding.Binding, ""))
+			continue
+		}
+
+		receiver := generatedComponentReceiver
+		for owner := graph; owner != contribution.graph; owner = owner.parent {
+			receiver = receiver + "." + parentFieldName
+		}
+
+		providerName := providerPrefix + ContributionName(multibinding.Provider)
+		assignments = append(assignments, NewProviderAssignment(receiver, providerName, nil))
+
+		target := &injectionTarget{contribution: multibinding}
+		if contribution.graph == graph {
+			dependencies = append(dependencies, target)
+		} else {
+			contribution.graph.delegate(target)
+		}
+	}
+
+	return &GeneratedMultibinding{
+		graph:                      graph,
+		generatedComponentReceiver: generatedComponentReceiver,
+		id:                         id,
+		multibindingType:           multibindingType,
+		multibindings:              multibindings,
+		assignments:                assignments,
+		dependencies:               dependencies,
+	}, nil
+}
+
+// ToSource returns the source code for this multibinding
+func (g *GeneratedMultibinding) ToSource(componentPackage string) string {
+	var builder strings.Builder
+	builder.WriteString("// Code generated by go generate; DO NOT EDIT.\n")
+	builder.WriteString("package " + componentPackage + "\n")
+
+	imports := make(map[string]string)
+	addImport(imports, injectPackage())
+	for _, pkg := range typePackages(g.multibindingType) {
+		addImport(imports, pkg)
+	}
+
+	for _, assignment := range g.assignments {
+		for _, pkg := range assignment.Packages() {
+			addImport(imports, pkg)
+		}
+	}
+
+	builder.WriteString("import (\n")
+	for packagePath, importName := range imports {
+		builder.WriteString("\t" + importName + " \"" + packagePath + "\"\n")
+	}
+	builder.WriteString(")\n")
+
+	multibindingType := typeSource(g.multibindingType, imports)
+	builder.WriteString(
+		"func " + g.graph.MultibindingName(g.id) +
+			"(" + g.generatedComponentReceiver + " *" + g.graph.generatedTypeName +
+			", " + resolutionParam(imports) + ") (" + multibindingType + ", error) {\n")
+
+	mapType, isMap := g.multibindingType.(*types.Map)
+	if isMap {
+		builder.WriteString(fmt.Sprintf("\telements := make(%s, %d)\n", multibindingType, len(g.assignments)))
+	} else {
+		builder.WriteString(fmt.Sprintf("\telements := make(%s, 0, %d)\n", multibindingType, len(g.assignments)))
+	}
+
+	for i, assignment := range g.assignments {
+		elementName := fmt.Sprintf("element%d", i)
+		builder.WriteString("\t" + elementName + ", err := " + assignment.GetSourceAssignment(imports) + "\n")
+		builder.WriteString("\tif err != nil {\n")
+		builder.WriteString("\t\treturn nil, err\n")
+		builder.WriteString("\t}\n")
+
+		if isMap {
+			key := g.multibindings[i].Key.ExactString()
+			if _, ok := mapType.Key().(*types.Named); ok {
+				key = "(" + typeSource(mapType.Key(), imports) + ")(" + key + ")"
+			}
+
+			elementType := typeSource(mapType.Elem(), imports)
+			builder.WriteString("\telements[" + key + "] = (" + elementType + ")(" + elementName + ")\n")
+		} else {
+			elementType := typeSource(g.multibindingType.(*types.Slice).Elem(), imports)
+			builder.WriteString("\telements = append(elements, (" + elementType + ")(" + elementName + "))\n")
+		}
+	}
+	builder.WriteString("\treturn elements, nil\n")
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
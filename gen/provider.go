@@ -3,10 +3,10 @@ package gen
 import (
 	"fmt"
 	"go/types"
-	"strconv"
 	"strings"
 
 	"github.com/dimes/dihedral/resolver"
+	"github.com/dimes/dihedral/typeutil"
 	"github.com/pkg/errors"
 )
 
@@ -17,9 +17,13 @@ const (
 // GeneratedModuleProvider is a single generated provider method on the component
 // from a module source
 type GeneratedModuleProvider struct {
-	generatedComponentType     string
+	graph                      *Graph
 	generatedComponentReceiver string
+	name                       string
 	resolvedType               *resolver.ModuleResolvedType
+	isSingleton                bool
+	hasLifecycle               bool // True if the provided type is an inject.Lifecycle
+	hasCloser                  bool // True if the provided type is closed instead of a cleanup function
 	assignments                []Assignment
 	dependencies               []*injectionTarget
 }
@@ -27,37 +31,57 @@ type GeneratedModuleProvider struct {
 // NewGeneratedProvider generates a provider function for the given resolved type
 // The generated function has the form:
 //
-// func (generatedComponent *GeneratedComponent) provides_Name() *SomeType {
+// func (generatedComponent *GeneratedComponent) provides_Name(resolution *inject.Cleanups) *SomeType {
 //     return someModule.providerFunc(
-//	       component.provides_ProvidedType(),
-//         InjectableFactory(component),
+//	       component.provides_ProvidedType(resolution),
+//         InjectableFactory(component, resolution),
 //     )
 // }
+//
+// Providers from modules marked with embeds.Singleton or a scope cache the provided
+// value on the component after the first successful call. The cache is guarded
+// by a lock, so concurrent callers never call the module method more than once. Cleanup
+// functions returned by the module method are added to the resolution. Provided values
+// with a `Close() error` method are closed instead if there is no cleanup function.
 func NewGeneratedProvider(
-	generatedComponentType string,
 	generatedComponentReceiver string,
 	resolvedType *resolver.ModuleResolvedType,
-	providers map[string]resolver.ResolvedType,
-	bindings map[string]*types.Named,
+	graph *Graph,
+) (*GeneratedModuleProvider, error) {
+	name := qualifiedName(resolvedType.Type, resolvedType.Qualifier)
+	return newGeneratedProvider(generatedComponentReceiver, name, resolvedType, graph)
+}
+
+// newGeneratedProvider generates a provider function whose name and singleton
+// fields are derived from the given name
+func newGeneratedProvider(
+	generatedComponentReceiver string,
+	name string,
+	resolvedType *resolver.ModuleResolvedType,
+	graph *Graph,
 ) (*GeneratedModuleProvider, error) {
 	assignments := make([]Assignment, 0)
 	dependencies := make([]*injectionTarget, 0)
 	signature := resolvedType.Method.Type().(*types.Signature)
 	for i := 0; i < signature.Params().Len(); i++ {
 		param := signature.Params().At(i)
-		assignment, err := AssignmentForFieldType(generatedComponentReceiver, param.Type(), providers, bindings)
+		assignment, err := AssignmentForFieldType(generatedComponentReceiver, param.Type(), "", graph)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Error generating binding for %+v", resolvedType)
 		}
 
 		assignments = append(assignments, assignment)
-		dependencies = append(dependencies, newInjectionTarget(param.Type()))
+		dependencies = append(dependencies, newInjectionTarget(param.Type(), ""))
 	}
 
 	return &GeneratedModuleProvider{
-		generatedComponentType:     generatedComponentType,
+		graph:                      graph,
 		generatedComponentReceiver: generatedComponentReceiver,
+		name:                       name,
 		resolvedType:               resolvedType,
+		isSingleton:                resolvedType.IsSingleton || resolvedType.Scope != nil,
+		hasLifecycle:               typeutil.HasLifecycle(resolvedType.Type),
+		hasCloser:                  !resolvedType.HasCleanup && typeutil.HasCloser(resolvedType.Type),
 		assignments:                assignments,
 		dependencies:               dependencies,
 	}, nil
@@ -65,32 +89,32 @@ func NewGeneratedProvider(
 
 // ToSource returns the source code for this provider.
 func (g *GeneratedModuleProvider) ToSource(componentPackage string) string {
-	moduleVariableName := SanitizeName(g.resolvedType.Module.Name)
-	returnType := "target_pkg." + g.resolvedType.Name.Obj().Name()
-	if g.resolvedType.IsPointer {
-		returnType = "*" + returnType
-	}
-
 	var builder strings.Builder
 	builder.WriteString("// Code generated by go generate; DO NOT EDIT.\n")
 	builder.WriteString("package " + componentPackage + "\n")
 
-	imports := map[string]string{
-		g.resolvedType.Name.Obj().Pkg().Path(): "target_pkg",
+	imports := make(map[string]string)
+	if g.resolvedType.Name != nil && g.resolvedType.Name.Obj().Pkg() != nil {
+		imports[g.resolvedType.Name.Obj().Pkg().Path()] = "target_pkg"
+	}
+	addTypeImports(imports, g.resolvedType.Type)
+	addImport(imports, injectPackage())
+	if g.resolvedType.Module == nil {
+		addImport(imports, g.resolvedType.Method.Pkg())
 	}
 
 	for _, assignment := range g.assignments {
-		castTo := assignment.CastTo()
-		if castTo == nil {
-			continue
+		for _, pkg := range assignment.Packages() {
+			addImport(imports, pkg)
 		}
 
-		packagePath := castTo.Obj().Pkg().Path()
-		if importName := imports[packagePath]; importName == "" {
-			imports[packagePath] = "di_import_" + strconv.Itoa(len(imports)+1)
+		if castTo := assignment.CastTo(); castTo != nil {
+			addTypeImports(imports, castTo)
 		}
 	}
 
+	returnType := typeSource(g.resolvedType.Type, imports)
+
 	builder.WriteString("import (\n")
 	for packagePath, importName := range imports {
 		builder.WriteString("\t" + importName + " \"" + packagePath + "\"\n")
@@ -98,12 +122,18 @@ func (g *GeneratedModuleProvider) ToSource(componentPackage string) string {
 	builder.WriteString(")\n")
 
 	builder.WriteString(
-		"func (" + g.generatedComponentReceiver + " *" + g.generatedComponentType + ") " +
-			ProviderName(g.resolvedType.Name) + "() (" + returnType + ", error) {\n")
+		"func (" + g.generatedComponentReceiver + " *" + g.graph.generatedTypeName + ") " +
+			providerPrefix + g.name + "(" + resolutionParam(imports) + ") (" + returnType + ", error) {\n")
+
+	singletonName := g.generatedComponentReceiver + "." + singletonPrefix + g.name
+	if g.isSingleton {
+		writeSingletonStart(&builder, imports, g.generatedComponentReceiver, singletonName,
+			typeutil.QualifiedID(g.resolvedType.Type, g.resolvedType.Qualifier), returnType)
+	}
 
 	for i, assignment := range g.assignments {
 		varName := fmt.Sprintf("param%d", i)
-		builder.WriteString("\t" + varName + ", err := " + assignment.GetSourceAssignment() + "\n")
+		builder.WriteString("\t" + varName + ", err := " + assignment.GetSourceAssignment(imports) + "\n")
 		builder.WriteString("\tif err != nil {\n")
 		builder.WriteString("\t\tvar zeroValue " + returnType + "\n")
 		builder.WriteString("\t\treturn zeroValue, err\n")
@@ -111,13 +141,22 @@ func (g *GeneratedModuleProvider) ToSource(componentPackage string) string {
 	}
 
 	returnAssignment := providerReturnValueName
+	if g.resolvedType.HasCleanup {
+		returnAssignment = returnAssignment + ", cleanup"
+	}
+
 	if g.resolvedType.HasError {
 		returnAssignment = returnAssignment + ", err"
 	}
 
+	// Constructor functions are called directly, module methods on the module of the component
+	callee := imports[g.resolvedType.Method.Pkg().Path()]
+	if g.resolvedType.Module != nil {
+		callee = g.generatedComponentReceiver + "." + SanitizeName(g.resolvedType.Module.Name)
+	}
+
 	builder.WriteString(
-		"\t" + returnAssignment + " := " + g.generatedComponentReceiver +
-			"." + moduleVariableName + "." + g.resolvedType.Method.Name() + "(\n")
+		"\t" + returnAssignment + " := " + callee + "." + g.resolvedType.Method.Name() + "(\n")
 
 	for i := range g.assignments {
 		varName := fmt.Sprintf("param%d", i)
@@ -125,13 +164,63 @@ func (g *GeneratedModuleProvider) ToSource(componentPackage string) string {
 	}
 	builder.WriteString("\t)\n")
 
+	addsCleanup := g.resolvedType.HasCleanup || g.hasCloser || g.hasLifecycle
+	if g.resolvedType.HasError && addsCleanup {
+		builder.WriteString("\tif err != nil {\n")
+		builder.WriteString("\t\tvar zeroValue " + returnType + "\n")
+		builder.WriteString("\t\treturn zeroValue, err\n")
+		builder.WriteString("\t}\n")
+	}
+
+	// Cleanup functions are run if resolving whatever depends on the value fails, or when
+	// the component is closed
+	if g.resolvedType.HasCleanup {
+		addCleanup := "AddFunc"
+		if g.resolvedType.CleanupHasError {
+			addCleanup = "Add"
+		}
+
+		builder.WriteString("\t" + resolutionParamName + "." + addCleanup + "(cleanup)\n")
+	}
+
+	// Provided values that are io.Closers are closed instead, unless the provider returns
+	// a cleanup function. A nil pointer or interface is not closed.
+	if g.hasCloser {
+		closeSource := "\t" + resolutionParamName + ".Add(" + providerReturnValueName + ".Close)\n"
+		if isNillable(g.resolvedType.Type) {
+			closeSource = "\tif " + providerReturnValueName + " != nil {\n\t" + closeSource + "\t}\n"
+		}
+
+		builder.WriteString(closeSource)
+	}
+
+	// Provided values are started and stopped with the component if their type is an
+	// inject.Lifecycle
+	if g.hasLifecycle {
+		builder.WriteString("\t" + resolutionParamName + ".AddLifecycle(" + providerReturnValueName + ")\n")
+	}
+
 	builder.WriteString("\treturn " + providerReturnValueName)
-	if g.resolvedType.HasError {
+	if g.resolvedType.HasError && !addsCleanup {
 		builder.WriteString(", err\n")
 	} else {
 		builder.WriteString(", nil\n")
 	}
 
+	if g.isSingleton {
+		writeSingletonEnd(&builder, singletonName, returnType)
+	}
+
 	builder.WriteString("}\n")
 	return builder.String()
 }
+
+// isNillable returns true if the given type is a pointer or an interface
+func isNillable(rawType types.Type) bool {
+	switch rawType.Underlying().(type) {
+	case *types.Pointer, *types.Interface:
+		return true
+	default:
+		return false
+	}
+}
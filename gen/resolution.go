@@ -0,0 +1,122 @@
+package gen
+
+import (
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+const (
+	resolutionParamName = "resolution"
+	resolutionContext   = resolutionParamName + ".Context()"
+	singletonValueName  = "value"
+)
+
+// injectPackage returns the package containing inject.Cleanups, which the generated code
+// uses to track the resources it creates
+func injectPackage() *types.Package {
+	return types.NewPackage(cleanupsType.PkgPath(), "inject")
+}
+
+// resolutionParam returns the declaration of the parameter that every generated factory
+// and provider takes. The resources created while resolving a target are added to it, so
+// that they can be cleaned up if resolving the target fails.
+func resolutionParam(imports map[string]string) string {
+	return resolutionParamName + " *" + imports[cleanupsType.PkgPath()] + "." + cleanupsType.Name()
+}
+
+// writeResolveStart writes the start of a call to inject.Resolve, which runs the code
+// written up to the matching writeResolveEnd in a resolution of its own. The resolution
+// carries the context that the given source evaluates to.
+func writeResolveStart(
+	builder *strings.Builder,
+	imports map[string]string,
+	receiver string,
+	valueName string,
+	returnType string,
+	context string,
+) {
+	writeResolveCall(builder, imports, receiver, valueName, "Resolve", returnType, context)
+}
+
+// writeResolveWithCleanupStart writes the start of a call to inject.ResolveWithCleanup,
+// which returns the function that cleans up the resolved value as cleanupName. The call
+// is ended by writeResolveEnd as well.
+func writeResolveWithCleanupStart(
+	builder *strings.Builder,
+	imports map[string]string,
+	receiver string,
+	valueName string,
+	cleanupName string,
+	returnType string,
+	context string,
+) {
+	writeResolveCall(builder, imports, receiver, valueName+", "+cleanupName, "ResolveWithCleanup",
+		returnType, context)
+}
+
+// writeResolveCall writes the start of a call to the given resolve function of the inject
+// package, whose results are assigned to the given names and an error
+func writeResolveCall(
+	builder *strings.Builder,
+	imports map[string]string,
+	receiver string,
+	resultNames string,
+	function string,
+	returnType string,
+	context string,
+) {
+	builder.WriteString(
+		"\t" + resultNames + ", err := " + imports[cleanupsType.PkgPath()] + "." + function + "(" + context +
+			", &" + receiver + "." + cleanupsFieldName + ", func(" + resolutionParam(imports) + ") (" +
+			returnType + ", error) {\n")
+}
+
+// writeResolveEnd writes the end of a call to inject.Resolve and returns the error if
+// resolving the value failed
+func writeResolveEnd(builder *strings.Builder, returnType string) {
+	builder.WriteString("\t})\n")
+	builder.WriteString("\tif err != nil {\n")
+	builder.WriteString("\t\tvar zeroValue " + returnType + "\n")
+	builder.WriteString("\t\treturn zeroValue, err\n")
+	builder.WriteString("\t}\n")
+}
+
+// writeSingletonStart writes the start of a function that returns the cached singleton
+// with the given name and ID. Cached singletons are returned without taking the lock.
+// Singletons are created in a resolution of their own, so that the resources they depend
+// on are owned by the component once the singleton is cached. The context of the
+// resolution marks the singleton as being created, see inject.SingletonLock.
+func writeSingletonStart(
+	builder *strings.Builder,
+	imports map[string]string,
+	receiver string,
+	singletonName string,
+	singletonID string,
+	returnType string,
+) {
+	lockName := singletonName + "_lock"
+	builder.WriteString("\tif " + lockName + ".Done() {\n")
+	builder.WriteString("\t\treturn " + singletonName + ", nil\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("\tif err := " + lockName + ".Lock(" + resolutionParamName + ", " +
+		strconv.Quote(singletonID) + "); err != nil {\n")
+	builder.WriteString("\t\tvar zeroValue " + returnType + "\n")
+	builder.WriteString("\t\treturn zeroValue, err\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("\tdefer " + lockName + ".Unlock()\n")
+	builder.WriteString("\tif " + lockName + ".Done() {\n")
+	builder.WriteString("\t\treturn " + singletonName + ", nil\n")
+	builder.WriteString("\t}\n")
+	writeResolveStart(builder, imports, receiver, singletonValueName, returnType,
+		lockName+".Creating("+resolutionParamName+")")
+}
+
+// writeSingletonEnd writes the end of a function started by writeSingletonStart, which
+// caches the created singleton
+func writeSingletonEnd(builder *strings.Builder, singletonName string, returnType string) {
+	writeResolveEnd(builder, returnType)
+	builder.WriteString("\t" + singletonName + " = " + singletonValueName + "\n")
+	builder.WriteString("\t" + singletonName + "_lock.MarkDone()\n")
+	builder.WriteString("\treturn " + singletonValueName + ", nil\n")
+}
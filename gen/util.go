@@ -2,7 +2,10 @@ package gen
 
 import (
 	"go/types"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/dimes/dihedral/typeutil"
 )
@@ -10,11 +13,118 @@ import (
 // SanitizeName returns a name that can be used as a Go identifier
 // from the given name. The generated identifier should not clash
 // with any other types.Named, unless the underlying named object
-// is the same
+// and its type arguments are the same
 func SanitizeName(name *types.Named) string {
-	sanitized := typeutil.IDFromNamed(name)
-	sanitized = strings.ReplaceAll(sanitized, "/", "_")
-	sanitized = strings.ReplaceAll(sanitized, ".", "_")
-	sanitized = strings.ReplaceAll(sanitized, "-", "_")
-	return sanitized
+	return sanitizeID(typeutil.IDFromNamed(name))
+}
+
+// qualifiedName returns a name that can be used as a Go identifier from the given
+// type and qualifier
+func qualifiedName(rawType types.Type, qualifier string) string {
+	name := sanitizeID(typeutil.TypeID(rawType))
+	if qualifier == "" {
+		return name
+	}
+
+	return name + "_name_" + qualifier
+}
+
+// typeSource returns the source code for the given type, using the given map of
+// package paths to import names
+func typeSource(rawType types.Type, imports map[string]string) string {
+	return types.TypeString(rawType, func(pkg *types.Package) string {
+		return imports[pkg.Path()]
+	})
+}
+
+// typePackages returns the packages that have to be imported to refer to the given type
+func typePackages(rawType types.Type) []*types.Package {
+	switch typed := rawType.(type) {
+	case *types.Named:
+		packages := make([]*types.Package, 0)
+		if typed.Obj().Pkg() != nil {
+			packages = append(packages, typed.Obj().Pkg())
+		}
+
+		for i := 0; i < typed.TypeArgs().Len(); i++ {
+			packages = append(packages, typePackages(typed.TypeArgs().At(i))...)
+		}
+		return packages
+	case *types.Pointer:
+		return typePackages(typed.Elem())
+	case *types.Slice:
+		return typePackages(typed.Elem())
+	case *types.Map:
+		return append(typePackages(typed.Key()), typePackages(typed.Elem())...)
+	case *types.Array:
+		return typePackages(typed.Elem())
+	case *types.Chan:
+		return typePackages(typed.Elem())
+	case *types.Signature:
+		return append(tuplePackages(typed.Params()), tuplePackages(typed.Results())...)
+	default:
+		return nil
+	}
+}
+
+// tuplePackages returns the packages that have to be imported to refer to the types
+// of the given parameters or results
+func tuplePackages(tuple *types.Tuple) []*types.Package {
+	var packages []*types.Package
+	for i := 0; i < tuple.Len(); i++ {
+		packages = append(packages, typePackages(tuple.At(i).Type())...)
+	}
+
+	return packages
+}
+
+// sanitizeID returns a name that can be used as a Go identifier from the given
+// ID returned by typeutil.IDFromType. Slices, the keys of maps and the type
+// arguments of generic types are spelled out so that they do not clash.
+func sanitizeID(id string) string {
+	var builder strings.Builder
+	var brackets []string
+	for i := 0; i < len(id); i++ {
+		switch {
+		case strings.HasPrefix(id[i:], "[]"):
+			builder.WriteString("slice_")
+			i++
+		case strings.HasPrefix(id[i:], "map["):
+			builder.WriteString("map_")
+			brackets = append(brackets, "_to_")
+			i += 3
+		case id[i] == '[':
+			builder.WriteString("_of_")
+			brackets = append(brackets, "_")
+		case id[i] == ']' && len(brackets) > 0:
+			builder.WriteString(brackets[len(brackets)-1])
+			brackets = brackets[:len(brackets)-1]
+		case id[i] == ',':
+			builder.WriteString("_and_")
+		case id[i] == '*':
+			builder.WriteString("ptr_")
+		case id[i] >= utf8.RuneSelf || id[i] == '_' ||
+			unicode.IsLetter(rune(id[i])) || unicode.IsDigit(rune(id[i])):
+			builder.WriteByte(id[i])
+		default:
+			builder.WriteString("_")
+		}
+	}
+
+	return builder.String()
+}
+
+// addImport adds the given package to the imports if it is not imported yet
+func addImport(imports map[string]string, pkg *types.Package) {
+	if _, ok := imports[pkg.Path()]; !ok {
+		imports[pkg.Path()] = "di_import_" + strconv.Itoa(len(imports)+1)
+	}
+}
+
+// addTypeImports adds the packages that are needed to refer to the given type to the
+// imports if they are not imported yet
+func addTypeImports(imports map[string]string, rawType types.Type) {
+	for _, pkg := range typePackages(rawType) {
+		addImport(imports, pkg)
+	}
 }
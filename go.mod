@@ -1,9 +1,16 @@
 module github.com/dimes/dihedral
 
-go 1.12
+go 1.25.0
 
 require (
 	github.com/pkg/errors v0.8.1
 	github.com/stretchr/testify v1.3.0
-	golang.org/x/tools v0.0.0-20190627033414-4874f863e654
+	golang.org/x/tools v0.44.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.35.0 // indirect
+	golang.org/x/sync v0.20.0 // indirect
 )
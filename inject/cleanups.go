@@ -0,0 +1,232 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrClosed is returned by the methods of a generated component after it is closed
+var ErrClosed = errors.New("Component is closed")
+
+// Cleanups collects the cleanup functions of the resources created by a generated
+// component, and runs them when the component is closed. It also collects the created
+// values that have to be started and stopped, see Lifecycle. A Cleanups created by Resolve
+// also carries the context of the resolution.
+//
+// The cleanup functions of values that are not cached are kept until the component is
+// closed as well, so every call to a target that creates such a value adds to them,
+// unless the target returns them to the caller, see ResolveWithCleanup.
+type Cleanups struct {
+	lock       sync.Mutex
+	ctx        context.Context
+	closed     bool
+	cleanups   []func() error
+	lifecycles []*lifecycleState
+}
+
+// Context returns the context that is passed to the providers and Init hooks of the
+// values created in this resolution
+func (c *Cleanups) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+// Add adds a cleanup function that is run when the component is closed. A nil function
+// is ignored.
+func (c *Cleanups) Add(cleanup func() error) {
+	if cleanup == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cleanups = append(c.cleanups, cleanup)
+}
+
+// AddFunc adds a cleanup function that cannot fail. A nil function is ignored.
+func (c *Cleanups) AddFunc(cleanup func()) {
+	if cleanup == nil {
+		return
+	}
+
+	c.Add(func() error {
+		cleanup()
+		return nil
+	})
+}
+
+// Close runs the cleanup functions in the reverse order in which they were added.
+// Every function is run, even if an earlier one fails. Returns nil if no function
+// fails, the error if exactly one fails, and a CleanupError otherwise. Once closed,
+// Resolve returns ErrClosed for these cleanups.
+func (c *Cleanups) Close() error {
+	c.lock.Lock()
+	cleanups := c.cleanups
+	c.cleanups = nil
+	c.closed = true
+	c.lock.Unlock()
+
+	var errs []error
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		if err := cleanups[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return joinErrors(errs)
+}
+
+// Resolve creates a value with the given function in a resolution that carries the given
+// context. The function adds the cleanup functions of the resources it creates to the
+// resolution. If the function fails, the resources are cleaned up right away. Otherwise,
+// their cleanup functions are moved to the given cleanups, along with the values to start
+// and stop. Generated components resolve every target and every singleton this way.
+// Returns ErrClosed if the given cleanups are closed.
+func Resolve[T any](
+	ctx context.Context,
+	cleanups *Cleanups,
+	create func(resolution *Cleanups) (T, error),
+) (T, error) {
+	var zeroValue T
+	if cleanups.isClosed() {
+		return zeroValue, ErrClosed
+	}
+
+	resolution := &Cleanups{ctx: ctx}
+	value, err := create(resolution)
+	if err != nil {
+		return zeroValue, rollback(resolution, err)
+	}
+
+	// The component may have been closed while the value was created
+	if !cleanups.take(resolution) {
+		return zeroValue, rollback(resolution, ErrClosed)
+	}
+
+	return value, nil
+}
+
+// ResolveWithCleanup creates a value like Resolve, but returns the function that runs the
+// cleanup functions of the resources created for the value instead of moving them to the
+// given cleanups. Singletons are still owned by the component, since they are created in
+// a resolution of their own, and so are the values to start and stop. Generated
+// components resolve targets that return a `func() error` this way.
+func ResolveWithCleanup[T any](
+	ctx context.Context,
+	cleanups *Cleanups,
+	create func(resolution *Cleanups) (T, error),
+) (T, func() error, error) {
+	var zeroValue T
+	if cleanups.isClosed() {
+		return zeroValue, nil, ErrClosed
+	}
+
+	resolution := &Cleanups{ctx: ctx}
+	value, err := create(resolution)
+	if err != nil {
+		return zeroValue, nil, rollback(resolution, err)
+	}
+
+	if !cleanups.takeLifecycles(resolution) {
+		return zeroValue, nil, rollback(resolution, ErrClosed)
+	}
+
+	return value, resolution.Close, nil
+}
+
+func (c *Cleanups) isClosed() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.closed
+}
+
+// take moves the cleanup functions and the values to start and stop of the given
+// resolution to these cleanups. Returns false if these cleanups are closed.
+func (c *Cleanups) take(resolution *Cleanups) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return false
+	}
+
+	resolution.lock.Lock()
+	defer resolution.lock.Unlock()
+	c.cleanups = append(c.cleanups, resolution.cleanups...)
+	c.lifecycles = append(c.lifecycles, resolution.lifecycles...)
+	resolution.cleanups = nil
+	resolution.lifecycles = nil
+	return true
+}
+
+// takeLifecycles moves the values to start and stop of the given resolution to these
+// cleanups. Returns false if these cleanups are closed.
+func (c *Cleanups) takeLifecycles(resolution *Cleanups) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return false
+	}
+
+	resolution.lock.Lock()
+	defer resolution.lock.Unlock()
+	c.lifecycles = append(c.lifecycles, resolution.lifecycles...)
+	resolution.lifecycles = nil
+	return true
+}
+
+// rollback cleans up the resources of the given resolution, whose value could not be
+// created because of the given error
+func rollback(resolution *Cleanups, err error) error {
+	if cleanupErr := resolution.Close(); cleanupErr != nil {
+		return &CleanupError{Errors: append([]error{err}, unwrapCleanupError(cleanupErr)...)}
+	}
+
+	return err
+}
+
+// joinErrors returns nil if there are no errors, the error if there is exactly one, and
+// a CleanupError otherwise
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return &CleanupError{Errors: errs}
+	}
+}
+
+func unwrapCleanupError(err error) []error {
+	if cleanupErr, ok := err.(*CleanupError); ok {
+		return cleanupErr.Errors
+	}
+
+	return []error{err}
+}
+
+// CleanupError contains the errors of several cleanup functions, in the order in
+// which the functions were run. If creating a value failed, its error comes first.
+type CleanupError struct {
+	Errors []error
+}
+
+// Error returns the messages of all errors
+func (c *CleanupError) Error() string {
+	messages := make([]string, len(c.Errors))
+	for i, err := range c.Errors {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the errors of the cleanup functions
+func (c *CleanupError) Unwrap() []error {
+	return c.Errors
+}
@@ -0,0 +1,76 @@
+// Package inject contains types that can be injected in place of a type to
+// change when and how often the type is created, or whether it is required
+package inject
+
+import (
+	"sync"
+)
+
+// Provider creates an instance of T every time it is called. Types marked as
+// singletons or with a scope are still only created once per component.
+type Provider[T any] func() (T, error)
+
+// Get returns an instance of T
+func (p Provider[T]) Get() (T, error) {
+	return p()
+}
+
+// Lazy creates an instance of T the first time it is called and returns that
+// instance from then on. Failed calls are not cached.
+type Lazy[T any] func() (T, error)
+
+// Get returns the instance of T, creating it if needed
+func (l Lazy[T]) Get() (T, error) {
+	return l()
+}
+
+// NewLazy returns a Lazy that creates its instance with the given provider. The
+// returned Lazy is safe for concurrent use.
+func NewLazy[T any](provider Provider[T]) Lazy[T] {
+	var lock sync.Mutex
+	var value T
+	done := false
+	return func() (T, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		if done {
+			return value, nil
+		}
+
+		created, err := provider()
+		if err != nil {
+			var zeroValue T
+			return zeroValue, err
+		}
+
+		value = created
+		done = true
+		return value, nil
+	}
+}
+
+// Optional contains an instance of T if T can be injected by the component.
+// Otherwise, it is empty.
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+// NewOptional returns an Optional containing the given value
+func NewOptional[T any](value T) Optional[T] {
+	return Optional[T]{
+		value:   value,
+		present: true,
+	}
+}
+
+// Get returns the value and true if the Optional contains a value, or the zero
+// value of T and false otherwise
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.present
+}
+
+// Present returns true if the Optional contains a value
+func (o Optional[T]) Present() bool {
+	return o.present
+}
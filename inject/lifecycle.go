@@ -0,0 +1,218 @@
+package inject
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultLifecycleTimeout limits how long starting or stopping a component takes if the
+// context passed to Start or Stop has no deadline. Pass a context with a deadline to use
+// a different timeout.
+const DefaultLifecycleTimeout = 30 * time.Second
+
+// Lifecycle is implemented by injected values that run in the background, like consumers
+// or schedulers. Generated components start them in the order in which they were created,
+// so dependencies are started before the values that depend on them, and stop them in the
+// reverse order.
+type Lifecycle interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+type lifecycleState struct {
+	lifecycle Lifecycle
+	started   bool
+	starting  chan struct{} // Closed once the running call to Start returns, or nil
+}
+
+// AddLifecycle adds a value that is started and stopped with the component
+func (c *Cleanups) AddLifecycle(lifecycle Lifecycle) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lifecycles = append(c.lifecycles, &lifecycleState{lifecycle: lifecycle})
+}
+
+// Start starts the added values that are not started yet, in the order in which they were
+// added. Values added after Start returns are started by the next call to Start. If a value
+// fails to start, the values after it are not started, and the values started by this call
+// are stopped again in the reverse order, with a new context that DefaultLifecycleTimeout
+// applies to. Returns the error of the value that failed to start, or a CleanupError with
+// that error first, followed by the errors of the values that failed to stop.
+//
+// If the context is done before a value is started, Start returns the error of the context
+// without waiting for the value. The value is still marked as started once its Start method
+// returns successfully, so it is stopped like the other values.
+func (c *Cleanups) Start(ctx context.Context) error {
+	ctx, cancel := lifecycleContext(ctx)
+	defer cancel()
+
+	c.lock.Lock()
+	lifecycles := append([]*lifecycleState{}, c.lifecycles...)
+	c.lock.Unlock()
+
+	started := make([]*lifecycleState, 0, len(lifecycles))
+	for _, state := range lifecycles {
+		if err := c.waitForStart(ctx, state); err != nil {
+			return c.rollbackStart(ctx, started, err)
+		}
+
+		if c.isStarted(state) {
+			continue
+		}
+
+		// The value is rolled back as well if it is started after the context is done
+		if err := c.start(ctx, state); err != nil {
+			return c.rollbackStart(ctx, append(started, state), err)
+		}
+
+		started = append(started, state)
+	}
+
+	return nil
+}
+
+// Stop stops the started values in the reverse order in which they were added. Every
+// value is stopped, even if stopping an earlier one fails. Once the context is done, Stop
+// no longer waits for values to stop, and returns the error of the context for each value
+// that is not stopped yet. Returns nil if no value fails to stop, the error if exactly one
+// fails, and a CleanupError otherwise.
+func (c *Cleanups) Stop(ctx context.Context) error {
+	ctx, cancel := lifecycleContext(ctx)
+	defer cancel()
+
+	c.lock.Lock()
+	lifecycles := append([]*lifecycleState{}, c.lifecycles...)
+	c.lock.Unlock()
+
+	return joinErrors(c.stop(ctx, lifecycles))
+}
+
+// start calls the Start method of the given value, and marks the value as started once the
+// method returns successfully, even if the context is done before that
+func (c *Cleanups) start(ctx context.Context, state *lifecycleState) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	starting := make(chan struct{})
+	c.lock.Lock()
+	state.starting = starting
+	c.lock.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		err := state.lifecycle.Start(ctx)
+		c.lock.Lock()
+		state.started = err == nil
+		state.starting = nil
+		c.lock.Unlock()
+		close(starting)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitForStart waits until the call to the Start method of the given value that is still
+// running, if any, returns. Returns the error of the context if it is done first.
+func (c *Cleanups) waitForStart(ctx context.Context, state *lifecycleState) error {
+	c.lock.Lock()
+	starting := state.starting
+	c.lock.Unlock()
+	if starting == nil {
+		return nil
+	}
+
+	select {
+	case <-starting:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rollbackStart stops the given values, which were started by a call to Start that failed
+// with the given error. The context of the failed call may be done, so the values are
+// stopped with a new context, which values that are still starting are waited for with.
+func (c *Cleanups) rollbackStart(ctx context.Context, started []*lifecycleState, err error) error {
+	ctx, cancel := lifecycleContext(context.WithoutCancel(ctx))
+	defer cancel()
+	if stopErrs := c.stop(ctx, started); len(stopErrs) > 0 {
+		return &CleanupError{Errors: append([]error{err}, stopErrs...)}
+	}
+
+	return err
+}
+
+// stop stops the given values that are started, in reverse order, and returns the errors
+// of the values that fail to stop. Values that are still starting are stopped once they
+// are started.
+func (c *Cleanups) stop(ctx context.Context, lifecycles []*lifecycleState) []error {
+	var errs []error
+	for i := len(lifecycles) - 1; i >= 0; i-- {
+		state := lifecycles[i]
+		if err := c.waitForStart(ctx, state); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !c.isStarted(state) {
+			continue
+		}
+
+		c.setStarted(state, false)
+		if err := callWithContext(ctx, state.lifecycle.Stop); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func (c *Cleanups) isStarted(state *lifecycleState) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return state.started
+}
+
+func (c *Cleanups) setStarted(state *lifecycleState, started bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	state.started = started
+}
+
+// lifecycleContext applies DefaultLifecycleTimeout to the given context if it has no
+// deadline
+func lifecycleContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, DefaultLifecycleTimeout)
+}
+
+// callWithContext calls the given function and returns the error of the context if the
+// context is done before the function returns. The function keeps running in that case,
+// and its error is dropped.
+func callWithContext(ctx context.Context, call func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- call(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
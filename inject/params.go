@@ -0,0 +1,46 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+)
+
+// paramKey is the key of a parameter of a target method in the context of a resolution
+type paramKey struct {
+	id string
+}
+
+// paramValue wraps a parameter in the context, so that a nil parameter can be told
+// apart from a missing one
+type paramValue struct {
+	value any
+}
+
+// WithParam returns a context that carries the given parameter of a target method. The
+// parameter is injected wherever the type with the given ID is injected in a resolution
+// that carries the context.
+func WithParam(ctx context.Context, id string, value any) context.Context {
+	return context.WithValue(ctx, paramKey{id: id}, paramValue{value: value})
+}
+
+// Param returns the parameter of a target method with the given ID, which the context of
+// the given resolution carries, see WithParam
+func Param[T any](resolution *Cleanups, id string) (T, error) {
+	var zeroValue T
+	param, ok := resolution.Context().Value(paramKey{id: id}).(paramValue)
+	if !ok {
+		return zeroValue, fmt.Errorf("%s is not a parameter of the target method", id)
+	}
+
+	// Nil interfaces are passed for parameters of interface types
+	if param.value == nil {
+		return zeroValue, nil
+	}
+
+	value, ok := param.value.(T)
+	if !ok {
+		return zeroValue, fmt.Errorf("Parameter %s has unexpected type %T", id, param.value)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,69 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// SingletonLock guards the creation of a singleton of a generated component. Like a
+// sync.Once, it only takes its mutex until the singleton is created, so later callers
+// only load an atomic flag. Unlike a sync.Mutex, it returns an error instead of
+// deadlocking if creating the singleton requires the singleton itself, e.g. through a
+// Lazy or Provider that is called while the singleton is created, even from another
+// goroutine. The singleton can only be detected through the context of its resolution,
+// so calling a method of the component from a goroutine that creating the singleton
+// waits for still deadlocks.
+type SingletonLock struct {
+	done     atomic.Bool
+	lock     sync.Mutex
+	creating *atomic.Bool
+}
+
+// singletonKey marks the context of the resolution that creates the singleton guarded by
+// the given lock. The marker is only active until the singleton is created, since Lazy and
+// Provider values keep the context after that.
+type singletonKey struct {
+	lock *SingletonLock
+}
+
+// Done returns true once the singleton is created
+func (s *SingletonLock) Done() bool {
+	return s.done.Load()
+}
+
+// Lock locks the singleton with the given name for the given resolution. Returns an error
+// if the resolution is part of creating the singleton.
+func (s *SingletonLock) Lock(resolution *Cleanups, name string) error {
+	if creating, ok := resolution.Context().Value(singletonKey{lock: s}).(*atomic.Bool); ok && creating.Load() {
+		return fmt.Errorf("%s depends on itself through a Lazy or Provider that is called while it is created", name)
+	}
+
+	s.lock.Lock()
+	return nil
+}
+
+// Creating returns the context in which the singleton is created by the given resolution.
+// Must be called while the singleton is locked.
+func (s *SingletonLock) Creating(resolution *Cleanups) context.Context {
+	s.creating = new(atomic.Bool)
+	s.creating.Store(true)
+	return context.WithValue(resolution.Context(), singletonKey{lock: s}, s.creating)
+}
+
+// MarkDone records that the singleton is created. Must be called while the singleton is
+// locked, after the singleton is stored.
+func (s *SingletonLock) MarkDone() {
+	s.done.Store(true)
+}
+
+// Unlock unlocks the singleton once it is created or creating it failed
+func (s *SingletonLock) Unlock() {
+	if s.creating != nil {
+		s.creating.Store(false)
+		s.creating = nil
+	}
+
+	s.lock.Unlock()
+}
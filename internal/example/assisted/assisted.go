@@ -0,0 +1,56 @@
+//go:generate dihedral -definition AssistedDefinition
+
+// Package assisted contains a component that creates structs from injected values
+// and values passed at runtime
+package assisted
+
+import (
+	"errors"
+
+	"github.com/dimes/dihedral/embeds"
+)
+
+// UserID identifies the user a handler handles requests for
+type UserID string
+
+// Database is shared by all handlers
+type Database struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+}
+
+// Handler handles the requests of a single user. The user ID and the limit are
+// passed to its assisted factory.
+type Handler struct {
+	inject   embeds.Inject
+	Database *Database
+	UserID   UserID `di:"assisted"`
+	Limit    int    `di:"assisted"`
+}
+
+// Init checks that the handler has a user
+func (h *Handler) Init() error {
+	if h.UserID == "" {
+		return errors.New("No user ID")
+	}
+
+	return nil
+}
+
+// Router creates a handler for every user
+type Router struct {
+	inject     embeds.Inject
+	Database   *Database
+	NewHandler func(UserID, int) (*Handler, error)
+}
+
+// AssistedDefinition defines the target and the modules to include
+type AssistedDefinition interface {
+	Target() AssistedComponent
+}
+
+// AssistedComponent is the component under test
+type AssistedComponent interface {
+	GetRouter() (*Router, error)
+	GetHandlerFactory() (func(UserID, int) (*Handler, error), error)
+}
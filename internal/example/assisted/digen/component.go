@@ -0,0 +1,51 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/assisted"
+)
+
+type DihedralAssistedComponent struct {
+	cleanups                                                                    di_import_2.Cleanups
+	singleton_github_com_dimes_dihedral_internal_example_assisted_Database      *di_import_1.Database
+	singleton_github_com_dimes_dihedral_internal_example_assisted_Database_lock di_import_2.SingletonLock
+}
+
+func NewDihedralAssistedComponent() *DihedralAssistedComponent {
+	return &DihedralAssistedComponent{}
+}
+func (d *DihedralAssistedComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralAssistedComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralAssistedComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralAssistedComponent) GetHandlerFactory() (func(di_import_1.UserID, int) (*di_import_1.Handler, error), error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (func(di_import_1.UserID, int) (*di_import_1.Handler, error), error) {
+		return func(assisted0 di_import_1.UserID, assisted1 int) (*di_import_1.Handler, error) {
+			return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Handler, error) {
+				return factory_github_com_dimes_dihedral_internal_example_assisted_Handler(d, resolution, assisted0, assisted1)
+			})
+		}, error(nil)
+	})
+	if err != nil {
+		var zeroValue func(di_import_1.UserID, int) (*di_import_1.Handler, error)
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralAssistedComponent) GetRouter() (*di_import_1.Router, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Router, error) {
+		return factory_github_com_dimes_dihedral_internal_example_assisted_Router(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Router
+		return zeroValue, err
+	}
+	return obj, nil
+}
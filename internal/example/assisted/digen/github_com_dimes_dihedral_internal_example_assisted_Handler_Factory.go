@@ -0,0 +1,24 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/assisted"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_assisted_Handler(d *DihedralAssistedComponent, resolution *di_import_2.Cleanups, assisted0 target_pkg.UserID, assisted1 int) (*target_pkg.Handler, error) {
+	target := &target_pkg.Handler{}
+	target.UserID = assisted0
+	target.Limit = assisted1
+	param0, err := factory_github_com_dimes_dihedral_internal_example_assisted_Database(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Handler
+		return zeroValue, err
+	}
+	target.Database = param0
+	if err := target.Init(); err != nil {
+		var zeroValue *target_pkg.Handler
+		return zeroValue, err
+	}
+	return target, nil
+}
@@ -0,0 +1,28 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/assisted"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_assisted_Router(d *DihedralAssistedComponent, resolution *di_import_2.Cleanups) (*target_pkg.Router, error) {
+	target := &target_pkg.Router{}
+	param0, err := factory_github_com_dimes_dihedral_internal_example_assisted_Database(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Router
+		return zeroValue, err
+	}
+	target.Database = param0
+	param1, err := func(assisted0 target_pkg.UserID, assisted1 int) (*target_pkg.Handler, error) {
+		return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Handler, error) {
+			return factory_github_com_dimes_dihedral_internal_example_assisted_Handler(d, resolution, assisted0, assisted1)
+		})
+	}, error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Router
+		return zeroValue, err
+	}
+	target.NewHandler = param1
+	return target, nil
+}
@@ -0,0 +1,160 @@
+//go:generate dihedral -definition BackgroundDefinition
+
+// Package background contains a component whose values run in the background and are
+// started and stopped with the component
+package background
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/dimes/dihedral/embeds"
+)
+
+// Options configure how the values behave when they are started and stopped
+type Options struct {
+	FailStart  bool
+	DelayStart bool
+	FailStop   bool
+	BlockStop  bool
+}
+
+// Events records the events of the values
+type Events struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+
+	lock   sync.Mutex
+	events []string
+}
+
+// Record appends an event
+func (e *Events) Record(event string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.events = append(e.events, event)
+}
+
+// List returns the recorded events
+func (e *Events) List() []string {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]string{}, e.events...)
+}
+
+// Queue is provided by the QueueModule
+type Queue struct {
+	events  *Events
+	options *Options
+}
+
+// Start starts the queue
+func (q *Queue) Start(ctx context.Context) error {
+	q.events.Record("queue started")
+	return nil
+}
+
+// Stop stops the queue
+func (q *Queue) Stop(ctx context.Context) error {
+	q.events.Record("queue stopped")
+	if q.options.FailStop {
+		return errors.New("Queue stop failed")
+	}
+
+	return nil
+}
+
+// Consumer consumes the queue
+type Consumer struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+	Events    *Events
+	Options   *Options
+	Queue     *Queue
+}
+
+// Start starts consuming
+func (c *Consumer) Start(ctx context.Context) error {
+	c.Events.Record("consumer started")
+	return nil
+}
+
+// Stop stops consuming
+func (c *Consumer) Stop(ctx context.Context) error {
+	c.Events.Record("consumer stopped")
+	if c.Options.FailStop {
+		return errors.New("Consumer stop failed")
+	}
+
+	return nil
+}
+
+// Scheduler schedules work for the consumer
+type Scheduler struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+	Consumer  *Consumer
+	Events    *Events
+	Options   *Options
+}
+
+// Start starts scheduling, or fails if the options say so. If the options say so, it only
+// starts once the context is done.
+func (s *Scheduler) Start(ctx context.Context) error {
+	if s.Options.FailStart {
+		return errors.New("Scheduler start failed")
+	}
+
+	if s.Options.DelayStart {
+		<-ctx.Done()
+	}
+
+	s.Events.Record("scheduler started")
+	return nil
+}
+
+// Stop stops scheduling. If the options say so, it blocks until the context is done.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.Options.BlockStop {
+		<-ctx.Done()
+	}
+
+	s.Events.Record("scheduler stopped")
+	return nil
+}
+
+// OptionsModule provides the options
+type OptionsModule struct {
+	provided embeds.ProvidedModule
+	Options  *Options
+}
+
+// ProvidesOptions provides the options
+func (o *OptionsModule) ProvidesOptions() *Options {
+	return o.Options
+}
+
+// QueueModule provides the queue
+type QueueModule struct {
+	singleton embeds.Singleton
+}
+
+// ProvidesQueue provides the queue
+func (q *QueueModule) ProvidesQueue(events *Events, options *Options) *Queue {
+	return &Queue{events: events, options: options}
+}
+
+// BackgroundDefinition defines the BackgroundComponent
+type BackgroundDefinition interface {
+	Modules() (*OptionsModule, *QueueModule)
+	Target() BackgroundComponent
+}
+
+// BackgroundComponent starts the scheduler, and everything it depends on, in the background
+type BackgroundComponent interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+	GetScheduler() *Scheduler
+	GetEvents() *Events
+}
@@ -0,0 +1,58 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/background"
+)
+
+type DihedralBackgroundComponent struct {
+	cleanups                                                                       di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_background_OptionsModule            *di_import_1.OptionsModule
+	github_com_dimes_dihedral_internal_example_background_QueueModule              *di_import_1.QueueModule
+	singleton_github_com_dimes_dihedral_internal_example_background_Queue          *di_import_1.Queue
+	singleton_github_com_dimes_dihedral_internal_example_background_Queue_lock     di_import_2.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_background_Scheduler      *di_import_1.Scheduler
+	singleton_github_com_dimes_dihedral_internal_example_background_Scheduler_lock di_import_2.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_background_Events         *di_import_1.Events
+	singleton_github_com_dimes_dihedral_internal_example_background_Events_lock    di_import_2.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_background_Consumer       *di_import_1.Consumer
+	singleton_github_com_dimes_dihedral_internal_example_background_Consumer_lock  di_import_2.SingletonLock
+}
+
+func NewDihedralBackgroundComponent(
+	github_com_dimes_dihedral_internal_example_background_OptionsModule *di_import_1.OptionsModule,
+) *DihedralBackgroundComponent {
+	return &DihedralBackgroundComponent{
+		github_com_dimes_dihedral_internal_example_background_OptionsModule: github_com_dimes_dihedral_internal_example_background_OptionsModule,
+		github_com_dimes_dihedral_internal_example_background_QueueModule:   &di_import_1.QueueModule{},
+	}
+}
+func (d *DihedralBackgroundComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralBackgroundComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralBackgroundComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralBackgroundComponent) GetEvents() *di_import_1.Events {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Events, error) {
+		return factory_github_com_dimes_dihedral_internal_example_background_Events(d, resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralBackgroundComponent) GetScheduler() *di_import_1.Scheduler {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Scheduler, error) {
+		return factory_github_com_dimes_dihedral_internal_example_background_Scheduler(d, resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
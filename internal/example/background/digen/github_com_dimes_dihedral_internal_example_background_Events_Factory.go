@@ -0,0 +1,32 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/background"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_background_Events(d *DihedralBackgroundComponent, resolution *di_import_2.Cleanups) (*target_pkg.Events, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_background_Events_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_background_Events, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_background_Events_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/background.Events"); err != nil {
+		var zeroValue *target_pkg.Events
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_background_Events_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_background_Events_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_background_Events, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_background_Events_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Events, error) {
+		target := &target_pkg.Events{}
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Events
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_background_Events = value
+	d.singleton_github_com_dimes_dihedral_internal_example_background_Events_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,46 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/background"
+)
+
+func (d *DihedralBackgroundComponent) provides_github_com_dimes_dihedral_internal_example_background_Queue(resolution *di_import_2.Cleanups) (*target_pkg.Queue, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_background_Queue_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_background_Queue, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_background_Queue_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/background.Queue"); err != nil {
+		var zeroValue *target_pkg.Queue
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_background_Queue_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_background_Queue_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_background_Queue, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_background_Queue_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Queue, error) {
+		param0, err := factory_github_com_dimes_dihedral_internal_example_background_Events(d, resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Queue
+			return zeroValue, err
+		}
+		param1, err := d.provides_github_com_dimes_dihedral_internal_example_background_Options(resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Queue
+			return zeroValue, err
+		}
+		returnValue := d.github_com_dimes_dihedral_internal_example_background_QueueModule.ProvidesQueue(
+			param0,
+			param1,
+		)
+		resolution.AddLifecycle(returnValue)
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Queue
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_background_Queue = value
+	d.singleton_github_com_dimes_dihedral_internal_example_background_Queue_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,51 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/background"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_background_Scheduler(d *DihedralBackgroundComponent, resolution *di_import_2.Cleanups) (*target_pkg.Scheduler, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_background_Scheduler_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_background_Scheduler, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_background_Scheduler_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/background.Scheduler"); err != nil {
+		var zeroValue *target_pkg.Scheduler
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_background_Scheduler_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_background_Scheduler_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_background_Scheduler, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_background_Scheduler_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Scheduler, error) {
+		target := &target_pkg.Scheduler{}
+		param0, err := factory_github_com_dimes_dihedral_internal_example_background_Consumer(d, resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Scheduler
+			return zeroValue, err
+		}
+		target.Consumer = param0
+		param1, err := factory_github_com_dimes_dihedral_internal_example_background_Events(d, resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Scheduler
+			return zeroValue, err
+		}
+		target.Events = param1
+		param2, err := d.provides_github_com_dimes_dihedral_internal_example_background_Options(resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Scheduler
+			return zeroValue, err
+		}
+		target.Options = param2
+		resolution.AddLifecycle(target)
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Scheduler
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_background_Scheduler = value
+	d.singleton_github_com_dimes_dihedral_internal_example_background_Scheduler_lock.MarkDone()
+	return value, nil
+}
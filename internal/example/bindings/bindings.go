@@ -6,6 +6,7 @@ package bindings
 import (
 	"time"
 
+	"github.com/dimes/dihedral/embeds"
 	"github.com/dimes/dihedral/internal/example"
 	"github.com/dimes/dihedral/internal/example/dbstore"
 )
@@ -23,6 +24,9 @@ import (
 //    be injected.
 // 5. InjectionDefinition: Contains a Target component and a list of modules to include for doing the
 //    injection
+//
+// Definitions can also be included in the modules of another definition. The target of the
+// included definition becomes a subcomponent, which can be created from the parent component.
 
 // BoundType is used to test whether or not bound types work on components
 type BoundType string
@@ -37,8 +41,11 @@ type BindingModule interface {
 
 // ServiceDefinition defines the target and the modules to include
 type ServiceDefinition interface {
-	// The list of modules to include
-	Modules() (BindingModule, *ServiceModule, dbstore.DBBindingModule)
+	// The list of modules to include. RequestDefinition is a subcomponent
+	Modules() (BindingModule, *ServiceModule, dbstore.DBBindingModule, RequestDefinition)
+
+	// The scope of the component. Types marked with this scope are created once per component
+	Scope() example.ApplicationScope
 
 	// An implementation of the interface will be automatically generated. The values
 	// returned will be automatically instiated from their dependencies.
@@ -57,11 +64,41 @@ type ServiceComponent interface {
 	GetServiceTimeout() (example.ServiceTimeout, error)
 
 	GetBoundType() BoundType
+
+	// Creates a subcomponent. The parameters are the provided modules of the subcomponent
+	NewRequestComponent(module *RequestModule) RequestComponent
+}
+
+// RequestDefinition defines a subcomponent that is created for every request
+type RequestDefinition interface {
+	Modules() *RequestModule
+	Scope() example.RequestScope
+	Target() RequestComponent
+}
+
+// RequestComponent can inject everything the ServiceComponent can, in addition
+// to the types provided by the RequestModule
+type RequestComponent interface {
+	GetRequestHandler() (*example.RequestHandler, error)
+}
+
+// RequestModule provides request specific values
+type RequestModule struct {
+	provided  embeds.ProvidedModule
+	RequestID example.RequestID
+}
+
+// ProvidesRequestID provides the ID of the current request
+func (r *RequestModule) ProvidesRequestID() example.RequestID {
+	return r.RequestID
 }
 
 // ServiceModule illustrates how each method on a struct module can provide
-// an instance to be injected
-type ServiceModule struct{}
+// an instance to be injected. Since the module is marked as a singleton, each
+// provided type is only created once per component.
+type ServiceModule struct {
+	singleton embeds.Singleton
+}
 
 // ProvidesServiceTimeout provides a time.Duration under the name ServiceTimeout
 func (s *ServiceModule) ProvidesServiceTimeout() (example.ServiceTimeout, error) {
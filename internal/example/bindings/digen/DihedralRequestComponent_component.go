@@ -0,0 +1,37 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_3 "github.com/dimes/dihedral/inject"
+	di_import_2 "github.com/dimes/dihedral/internal/example"
+	di_import_1 "github.com/dimes/dihedral/internal/example/bindings"
+)
+
+type DihedralRequestComponent struct {
+	parent                                                                   *DihedralServiceComponent
+	cleanups                                                                 di_import_3.Cleanups
+	github_com_dimes_dihedral_internal_example_bindings_RequestModule        *di_import_1.RequestModule
+	singleton_github_com_dimes_dihedral_internal_example_RequestHandler      *di_import_2.RequestHandler
+	singleton_github_com_dimes_dihedral_internal_example_RequestHandler_lock di_import_3.SingletonLock
+}
+
+func (d *DihedralRequestComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralRequestComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralRequestComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralRequestComponent) GetRequestHandler() (*di_import_2.RequestHandler, error) {
+	obj, err := di_import_3.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_3.Cleanups) (*di_import_2.RequestHandler, error) {
+		return factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_RequestHandler(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_2.RequestHandler
+		return zeroValue, err
+	}
+	return obj, nil
+}
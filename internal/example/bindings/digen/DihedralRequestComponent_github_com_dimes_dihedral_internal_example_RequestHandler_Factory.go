@@ -0,0 +1,57 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example"
+	di_import_3 "github.com/dimes/dihedral/internal/example/dbstore"
+)
+
+func factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_RequestHandler(d *DihedralRequestComponent, resolution *di_import_2.Cleanups) (*target_pkg.RequestHandler, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_RequestHandler_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_RequestHandler, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_RequestHandler_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example.RequestHandler"); err != nil {
+		var zeroValue *target_pkg.RequestHandler
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_RequestHandler_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_RequestHandler_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_RequestHandler, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_RequestHandler_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.RequestHandler, error) {
+		target := &target_pkg.RequestHandler{}
+		param0, err := d.provides_github_com_dimes_dihedral_internal_example_RequestID(resolution)
+		if err != nil {
+			var zeroValue *target_pkg.RequestHandler
+			return zeroValue, err
+		}
+		target.RequestID = param0
+		param1, err := factory_github_com_dimes_dihedral_internal_example_Service(d.parent, resolution)
+		if err != nil {
+			var zeroValue *target_pkg.RequestHandler
+			return zeroValue, err
+		}
+		target.Service = param1
+		param2, err := factory_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore(d.parent, resolution)
+		if err != nil {
+			var zeroValue *target_pkg.RequestHandler
+			return zeroValue, err
+		}
+		target.DBStore = (di_import_3.DBStore)(param2)
+		param3, err := factory_github_com_dimes_dihedral_internal_example_RequestCounter(d.parent, resolution)
+		if err != nil {
+			var zeroValue *target_pkg.RequestHandler
+			return zeroValue, err
+		}
+		target.Counter = param3
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.RequestHandler
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_RequestHandler = value
+	d.singleton_github_com_dimes_dihedral_internal_example_RequestHandler_lock.MarkDone()
+	return value, nil
+}
@@ -2,33 +2,70 @@
 package digen
 
 import (
+	"context"
+	di_import_4 "github.com/dimes/dihedral/inject"
 	di_import_3 "github.com/dimes/dihedral/internal/example"
-	di_import_1 "github.com/dimes/dihedral/internal/example/bindings"
-	di_import_2 "github.com/dimes/dihedral/internal/example/dbstore"
+	di_import_2 "github.com/dimes/dihedral/internal/example/bindings"
+	di_import_1 "github.com/dimes/dihedral/internal/example/dbstore"
 )
 
 type DihedralServiceComponent struct {
-	github_com_dimes_dihedral_internal_example_bindings_ServiceModule   *di_import_1.ServiceModule
-	github_com_dimes_dihedral_internal_example_dbstore_DBProviderModule *di_import_2.DBProviderModule
+	cleanups                                                                             di_import_4.Cleanups
+	github_com_dimes_dihedral_internal_example_dbstore_DBProviderModule                  *di_import_1.DBProviderModule
+	github_com_dimes_dihedral_internal_example_bindings_ServiceModule                    *di_import_2.ServiceModule
+	singleton_github_com_dimes_dihedral_internal_example_ServiceTimeout                  di_import_3.ServiceTimeout
+	singleton_github_com_dimes_dihedral_internal_example_ServiceTimeout_lock             di_import_4.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType      di_import_2.SpecificBoundType
+	singleton_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType_lock di_import_4.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore           *di_import_1.MemoryDBStore
+	singleton_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore_lock      di_import_4.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_RequestCounter                  *di_import_3.RequestCounter
+	singleton_github_com_dimes_dihedral_internal_example_RequestCounter_lock             di_import_4.SingletonLock
 }
 
 func NewDihedralServiceComponent(
-	github_com_dimes_dihedral_internal_example_dbstore_DBProviderModule *di_import_2.DBProviderModule,
+	github_com_dimes_dihedral_internal_example_dbstore_DBProviderModule *di_import_1.DBProviderModule,
 ) *DihedralServiceComponent {
 	return &DihedralServiceComponent{
-		github_com_dimes_dihedral_internal_example_bindings_ServiceModule:   &di_import_1.ServiceModule{},
 		github_com_dimes_dihedral_internal_example_dbstore_DBProviderModule: github_com_dimes_dihedral_internal_example_dbstore_DBProviderModule,
+		github_com_dimes_dihedral_internal_example_bindings_ServiceModule:   &di_import_2.ServiceModule{},
 	}
 }
-func (d *DihedralServiceComponent) GetBoundType() di_import_1.BoundType {
-	obj, err := d.provides_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType()
+func (d *DihedralServiceComponent) NewRequestComponent(
+	github_com_dimes_dihedral_internal_example_bindings_RequestModule *di_import_2.RequestModule,
+) di_import_2.RequestComponent {
+	return &DihedralRequestComponent{
+		parent: d,
+		github_com_dimes_dihedral_internal_example_bindings_RequestModule: github_com_dimes_dihedral_internal_example_bindings_RequestModule,
+	}
+}
+func (d *DihedralServiceComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralServiceComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralServiceComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralServiceComponent) GetBoundType() di_import_2.BoundType {
+	obj, err := di_import_4.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_4.Cleanups) (di_import_2.BoundType, error) {
+		value, err := d.provides_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType(resolution)
+		if err != nil {
+			var zeroValue di_import_2.BoundType
+			return zeroValue, err
+		}
+		return (di_import_2.BoundType)(value), nil
+	})
 	if err != nil {
 		panic(err)
 	}
-	return (di_import_1.BoundType)(obj)
+	return obj
 }
 func (d *DihedralServiceComponent) GetService() (*di_import_3.Service, error) {
-	obj, err := factory_github_com_dimes_dihedral_internal_example_Service(d)
+	obj, err := di_import_4.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_4.Cleanups) (*di_import_3.Service, error) {
+		return factory_github_com_dimes_dihedral_internal_example_Service(d, resolution)
+	})
 	if err != nil {
 		var zeroValue *di_import_3.Service
 		return zeroValue, err
@@ -36,7 +73,9 @@ func (d *DihedralServiceComponent) GetService() (*di_import_3.Service, error) {
 	return obj, nil
 }
 func (d *DihedralServiceComponent) GetServiceTimeout() (di_import_3.ServiceTimeout, error) {
-	obj, err := d.provides_github_com_dimes_dihedral_internal_example_ServiceTimeout()
+	obj, err := di_import_4.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_4.Cleanups) (di_import_3.ServiceTimeout, error) {
+		return d.provides_github_com_dimes_dihedral_internal_example_ServiceTimeout(resolution)
+	})
 	if err != nil {
 		var zeroValue di_import_3.ServiceTimeout
 		return zeroValue, err
@@ -0,0 +1,32 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_RequestCounter(d *DihedralServiceComponent, resolution *di_import_2.Cleanups) (*target_pkg.RequestCounter, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_RequestCounter_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_RequestCounter, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_RequestCounter_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example.RequestCounter"); err != nil {
+		var zeroValue *target_pkg.RequestCounter
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_RequestCounter_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_RequestCounter_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_RequestCounter, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_RequestCounter_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.RequestCounter, error) {
+		target := &target_pkg.RequestCounter{}
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.RequestCounter
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_RequestCounter = value
+	d.singleton_github_com_dimes_dihedral_internal_example_RequestCounter_lock.MarkDone()
+	return value, nil
+}
@@ -2,10 +2,31 @@
 package digen
 
 import (
+	di_import_2 "github.com/dimes/dihedral/inject"
 	target_pkg "github.com/dimes/dihedral/internal/example"
 )
 
-func (d *DihedralServiceComponent) provides_github_com_dimes_dihedral_internal_example_ServiceTimeout() (target_pkg.ServiceTimeout, error) {
-	returnValue, err := d.github_com_dimes_dihedral_internal_example_bindings_ServiceModule.ProvidesServiceTimeout()
-	return returnValue, err
+func (d *DihedralServiceComponent) provides_github_com_dimes_dihedral_internal_example_ServiceTimeout(resolution *di_import_2.Cleanups) (target_pkg.ServiceTimeout, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_ServiceTimeout_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_ServiceTimeout, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_ServiceTimeout_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example.ServiceTimeout"); err != nil {
+		var zeroValue target_pkg.ServiceTimeout
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_ServiceTimeout_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_ServiceTimeout_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_ServiceTimeout, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_ServiceTimeout_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (target_pkg.ServiceTimeout, error) {
+		returnValue, err := d.github_com_dimes_dihedral_internal_example_bindings_ServiceModule.ProvidesServiceTimeout()
+		return returnValue, err
+	})
+	if err != nil {
+		var zeroValue target_pkg.ServiceTimeout
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_ServiceTimeout = value
+	d.singleton_github_com_dimes_dihedral_internal_example_ServiceTimeout_lock.MarkDone()
+	return value, nil
 }
@@ -2,18 +2,19 @@
 package digen
 
 import (
+	di_import_2 "github.com/dimes/dihedral/inject"
 	target_pkg "github.com/dimes/dihedral/internal/example"
 )
 
-func factory_github_com_dimes_dihedral_internal_example_Service(d *DihedralServiceComponent) (*target_pkg.Service, error) {
+func factory_github_com_dimes_dihedral_internal_example_Service(d *DihedralServiceComponent, resolution *di_import_2.Cleanups) (*target_pkg.Service, error) {
 	target := &target_pkg.Service{}
-	param0, err := d.provides_github_com_dimes_dihedral_internal_example_ServiceTimeout()
+	param0, err := d.provides_github_com_dimes_dihedral_internal_example_ServiceTimeout(resolution)
 	if err != nil {
 		var zeroValue *target_pkg.Service
 		return zeroValue, err
 	}
 	target.ServiceTimeout = param0
-	param1, err := factory_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore(d)
+	param1, err := factory_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore(d, resolution)
 	if err != nil {
 		var zeroValue *target_pkg.Service
 		return zeroValue, err
@@ -2,10 +2,31 @@
 package digen
 
 import (
+	di_import_2 "github.com/dimes/dihedral/inject"
 	target_pkg "github.com/dimes/dihedral/internal/example/bindings"
 )
 
-func (d *DihedralServiceComponent) provides_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType() (target_pkg.SpecificBoundType, error) {
-	returnValue := d.github_com_dimes_dihedral_internal_example_bindings_ServiceModule.ProvidesSpecificBoundType()
-	return returnValue, nil
+func (d *DihedralServiceComponent) provides_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType(resolution *di_import_2.Cleanups) (target_pkg.SpecificBoundType, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/bindings.SpecificBoundType"); err != nil {
+		var zeroValue target_pkg.SpecificBoundType
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (target_pkg.SpecificBoundType, error) {
+		returnValue := d.github_com_dimes_dihedral_internal_example_bindings_ServiceModule.ProvidesSpecificBoundType()
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue target_pkg.SpecificBoundType
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType = value
+	d.singleton_github_com_dimes_dihedral_internal_example_bindings_SpecificBoundType_lock.MarkDone()
+	return value, nil
 }
@@ -2,10 +2,11 @@
 package digen
 
 import (
+	di_import_2 "github.com/dimes/dihedral/inject"
 	target_pkg "github.com/dimes/dihedral/internal/example/dbstore"
 )
 
-func (d *DihedralServiceComponent) provides_github_com_dimes_dihedral_internal_example_dbstore_DBProviderPrefix() (target_pkg.DBProviderPrefix, error) {
+func (d *DihedralServiceComponent) provides_github_com_dimes_dihedral_internal_example_dbstore_DBProviderPrefix(resolution *di_import_2.Cleanups) (target_pkg.DBProviderPrefix, error) {
 	returnValue := d.github_com_dimes_dihedral_internal_example_dbstore_DBProviderModule.ProvidesPrefix()
 	return returnValue, nil
 }
@@ -2,16 +2,36 @@
 package digen
 
 import (
+	di_import_2 "github.com/dimes/dihedral/inject"
 	target_pkg "github.com/dimes/dihedral/internal/example/dbstore"
 )
 
-func factory_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore(d *DihedralServiceComponent) (*target_pkg.MemoryDBStore, error) {
-	target := &target_pkg.MemoryDBStore{}
-	param0, err := d.provides_github_com_dimes_dihedral_internal_example_dbstore_DBProviderPrefix()
+func factory_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore(d *DihedralServiceComponent, resolution *di_import_2.Cleanups) (*target_pkg.MemoryDBStore, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/dbstore.MemoryDBStore"); err != nil {
+		var zeroValue *target_pkg.MemoryDBStore
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.MemoryDBStore, error) {
+		param0, err := d.provides_github_com_dimes_dihedral_internal_example_dbstore_DBProviderPrefix(resolution)
+		if err != nil {
+			var zeroValue *target_pkg.MemoryDBStore
+			return zeroValue, err
+		}
+		target := target_pkg.NewMemoryDBStore((target_pkg.Prefix)(param0))
+		return target, nil
+	})
 	if err != nil {
 		var zeroValue *target_pkg.MemoryDBStore
 		return zeroValue, err
 	}
-	target.Prefix = (target_pkg.Prefix)(param0)
-	return target, nil
+	d.singleton_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore = value
+	d.singleton_github_com_dimes_dihedral_internal_example_dbstore_MemoryDBStore_lock.MarkDone()
+	return value, nil
 }
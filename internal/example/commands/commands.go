@@ -0,0 +1,82 @@
+//go:generate dihedral -definition CommandsDefinition
+
+// Package commands contains a component that collects commands into maps
+// keyed by their names
+package commands
+
+import (
+	"github.com/dimes/dihedral/embeds"
+)
+
+// CommandName is the name a command is run with
+type CommandName string
+
+// The names of the commands
+const (
+	Deploy   CommandName = "deploy"
+	Rollback CommandName = "rollback"
+)
+
+// Command is a command of the CLI
+type Command interface {
+	Run() string
+}
+
+// DeployCommand is bound into the commands by a binding module
+type DeployCommand struct {
+	inject embeds.Inject
+}
+
+// Run runs the command
+func (d *DeployCommand) Run() string {
+	return "deployed"
+}
+
+// RollbackCommand is provided into the commands by a provider module
+type RollbackCommand struct{}
+
+// Run runs the command
+func (r *RollbackCommand) Run() string {
+	return "rolled back"
+}
+
+// CLI runs commands by name
+type CLI struct {
+	inject   embeds.Inject
+	Commands map[CommandName]Command
+	Aliases  map[string]Command
+}
+
+// CommandBindingModule binds the DeployCommand into the commands
+type CommandBindingModule interface {
+	//di:map Deploy
+	BindsDeployCommand(impl *DeployCommand) Command
+}
+
+// CommandProviderModule provides commands and aliases
+type CommandProviderModule struct{}
+
+// ProvidesRollbackCommand provides the rollback command
+//
+//di:map Rollback
+func (c *CommandProviderModule) ProvidesRollbackCommand() Command {
+	return &RollbackCommand{}
+}
+
+// ProvidesUndoAlias provides an alias for the rollback command
+//
+//di:map "undo"
+func (c *CommandProviderModule) ProvidesUndoAlias() Command {
+	return &RollbackCommand{}
+}
+
+// CommandsDefinition defines the CommandsComponent
+type CommandsDefinition interface {
+	Modules() (CommandBindingModule, *CommandProviderModule)
+	Target() CommandsComponent
+}
+
+// CommandsComponent returns the CLI
+type CommandsComponent interface {
+	GetCLI() (*CLI, error)
+}
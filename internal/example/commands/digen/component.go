@@ -0,0 +1,38 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/commands"
+)
+
+type DihedralCommandsComponent struct {
+	cleanups                                                                  di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_commands_CommandProviderModule *di_import_1.CommandProviderModule
+}
+
+func NewDihedralCommandsComponent() *DihedralCommandsComponent {
+	return &DihedralCommandsComponent{
+		github_com_dimes_dihedral_internal_example_commands_CommandProviderModule: &di_import_1.CommandProviderModule{},
+	}
+}
+func (d *DihedralCommandsComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralCommandsComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralCommandsComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralCommandsComponent) GetCLI() (*di_import_1.CLI, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.CLI, error) {
+		return factory_github_com_dimes_dihedral_internal_example_commands_CLI(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.CLI
+		return zeroValue, err
+	}
+	return obj, nil
+}
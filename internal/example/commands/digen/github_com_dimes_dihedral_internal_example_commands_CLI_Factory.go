@@ -0,0 +1,24 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/commands"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_commands_CLI(d *DihedralCommandsComponent, resolution *di_import_2.Cleanups) (*target_pkg.CLI, error) {
+	target := &target_pkg.CLI{}
+	param0, err := multibinds_map_github_com_dimes_dihedral_internal_example_commands_CommandName_to_github_com_dimes_dihedral_internal_example_commands_Command(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.CLI
+		return zeroValue, err
+	}
+	target.Commands = param0
+	param1, err := multibinds_map_string_to_github_com_dimes_dihedral_internal_example_commands_Command(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.CLI
+		return zeroValue, err
+	}
+	target.Aliases = param1
+	return target, nil
+}
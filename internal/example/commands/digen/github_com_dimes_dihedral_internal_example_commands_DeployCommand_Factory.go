@@ -0,0 +1,12 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/commands"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_commands_DeployCommand(d *DihedralCommandsComponent, resolution *di_import_2.Cleanups) (*target_pkg.DeployCommand, error) {
+	target := &target_pkg.DeployCommand{}
+	return target, nil
+}
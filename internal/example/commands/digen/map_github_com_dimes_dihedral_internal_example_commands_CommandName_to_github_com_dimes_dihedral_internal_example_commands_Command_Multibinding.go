@@ -0,0 +1,22 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_1 "github.com/dimes/dihedral/inject"
+	di_import_2 "github.com/dimes/dihedral/internal/example/commands"
+)
+
+func multibinds_map_github_com_dimes_dihedral_internal_example_commands_CommandName_to_github_com_dimes_dihedral_internal_example_commands_Command(d *DihedralCommandsComponent, resolution *di_import_1.Cleanups) (map[di_import_2.CommandName]di_import_2.Command, error) {
+	elements := make(map[di_import_2.CommandName]di_import_2.Command, 2)
+	element0, err := factory_github_com_dimes_dihedral_internal_example_commands_DeployCommand(d, resolution)
+	if err != nil {
+		return nil, err
+	}
+	elements[(di_import_2.CommandName)("deploy")] = (di_import_2.Command)(element0)
+	element1, err := d.provides_github_com_dimes_dihedral_internal_example_commands_CommandProviderModule_ProvidesRollbackCommand(resolution)
+	if err != nil {
+		return nil, err
+	}
+	elements[(di_import_2.CommandName)("rollback")] = (di_import_2.Command)(element1)
+	return elements, nil
+}
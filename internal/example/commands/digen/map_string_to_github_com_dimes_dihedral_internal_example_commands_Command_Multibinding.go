@@ -0,0 +1,17 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_1 "github.com/dimes/dihedral/inject"
+	di_import_2 "github.com/dimes/dihedral/internal/example/commands"
+)
+
+func multibinds_map_string_to_github_com_dimes_dihedral_internal_example_commands_Command(d *DihedralCommandsComponent, resolution *di_import_1.Cleanups) (map[string]di_import_2.Command, error) {
+	elements := make(map[string]di_import_2.Command, 1)
+	element0, err := d.provides_github_com_dimes_dihedral_internal_example_commands_CommandProviderModule_ProvidesUndoAlias(resolution)
+	if err != nil {
+		return nil, err
+	}
+	elements["undo"] = (di_import_2.Command)(element0)
+	return elements, nil
+}
@@ -0,0 +1,65 @@
+//go:generate dihedral -definition ConcurrencyDefinition
+
+// Package concurrency contains a component whose singletons are requested from
+// many goroutines at once
+package concurrency
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/dimes/dihedral/embeds"
+	"github.com/dimes/dihedral/inject"
+)
+
+// Connection is an expensive resource that should only be created once
+type Connection struct {
+	Attempt int32
+}
+
+// Pool is a singleton injected struct that depends on a singleton provided type
+type Pool struct {
+	inject     embeds.Inject
+	singleton  embeds.Singleton
+	Connection *Connection
+}
+
+// Dispatcher creates the pool through a Provider when it is first used, possibly from
+// many goroutines at once
+type Dispatcher struct {
+	inject embeds.Inject
+	Pools  inject.Provider[*Pool]
+}
+
+// ConnectionModule provides the Connection. The first Failures calls
+// to ProvidesConnection return an error. Attempts is updated atomically.
+type ConnectionModule struct {
+	provided  embeds.ProvidedModule
+	singleton embeds.Singleton
+
+	Failures int32
+	Attempts int32
+}
+
+// ProvidesConnection provides the Connection
+func (c *ConnectionModule) ProvidesConnection() (*Connection, error) {
+	attempt := atomic.AddInt32(&c.Attempts, 1)
+	if attempt <= c.Failures {
+		return nil, errors.New("connection failed")
+	}
+
+	return &Connection{Attempt: attempt}, nil
+}
+
+// ConcurrencyDefinition defines the target and the modules to include
+type ConcurrencyDefinition interface {
+	Modules() *ConnectionModule
+	Target() ConcurrencyComponent
+}
+
+// ConcurrencyComponent is the component under test
+type ConcurrencyComponent interface {
+	GetPool() (*Pool, error)
+	GetConnection() (*Connection, error)
+	GetDispatcher() *Dispatcher
+}
@@ -0,0 +1,63 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/concurrency"
+)
+
+type DihedralConcurrencyComponent struct {
+	cleanups                                                                         di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_concurrency_ConnectionModule          *di_import_1.ConnectionModule
+	singleton_github_com_dimes_dihedral_internal_example_concurrency_Connection      *di_import_1.Connection
+	singleton_github_com_dimes_dihedral_internal_example_concurrency_Connection_lock di_import_2.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_concurrency_Pool            *di_import_1.Pool
+	singleton_github_com_dimes_dihedral_internal_example_concurrency_Pool_lock       di_import_2.SingletonLock
+}
+
+func NewDihedralConcurrencyComponent(
+	github_com_dimes_dihedral_internal_example_concurrency_ConnectionModule *di_import_1.ConnectionModule,
+) *DihedralConcurrencyComponent {
+	return &DihedralConcurrencyComponent{
+		github_com_dimes_dihedral_internal_example_concurrency_ConnectionModule: github_com_dimes_dihedral_internal_example_concurrency_ConnectionModule,
+	}
+}
+func (d *DihedralConcurrencyComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralConcurrencyComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralConcurrencyComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralConcurrencyComponent) GetConnection() (*di_import_1.Connection, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Connection, error) {
+		return d.provides_github_com_dimes_dihedral_internal_example_concurrency_Connection(resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Connection
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralConcurrencyComponent) GetDispatcher() *di_import_1.Dispatcher {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Dispatcher, error) {
+		return factory_github_com_dimes_dihedral_internal_example_concurrency_Dispatcher(d, resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralConcurrencyComponent) GetPool() (*di_import_1.Pool, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Pool, error) {
+		return factory_github_com_dimes_dihedral_internal_example_concurrency_Pool(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Pool
+		return zeroValue, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,32 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/concurrency"
+)
+
+func (d *DihedralConcurrencyComponent) provides_github_com_dimes_dihedral_internal_example_concurrency_Connection(resolution *di_import_2.Cleanups) (*target_pkg.Connection, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Connection_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Connection, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Connection_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/concurrency.Connection"); err != nil {
+		var zeroValue *target_pkg.Connection
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Connection_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Connection_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Connection, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Connection_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Connection, error) {
+		returnValue, err := d.github_com_dimes_dihedral_internal_example_concurrency_ConnectionModule.ProvidesConnection()
+		return returnValue, err
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Connection
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Connection = value
+	d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Connection_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,22 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/concurrency"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_concurrency_Dispatcher(d *DihedralConcurrencyComponent, resolution *di_import_2.Cleanups) (*target_pkg.Dispatcher, error) {
+	target := &target_pkg.Dispatcher{}
+	param0, err := di_import_2.Provider[*target_pkg.Pool](func() (*target_pkg.Pool, error) {
+		return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Pool, error) {
+			return factory_github_com_dimes_dihedral_internal_example_concurrency_Pool(d, resolution)
+		})
+	}), error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Dispatcher
+		return zeroValue, err
+	}
+	target.Pools = param0
+	return target, nil
+}
@@ -0,0 +1,38 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/concurrency"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_concurrency_Pool(d *DihedralConcurrencyComponent, resolution *di_import_2.Cleanups) (*target_pkg.Pool, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Pool_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Pool, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Pool_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/concurrency.Pool"); err != nil {
+		var zeroValue *target_pkg.Pool
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Pool_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Pool_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Pool, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Pool_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Pool, error) {
+		target := &target_pkg.Pool{}
+		param0, err := d.provides_github_com_dimes_dihedral_internal_example_concurrency_Connection(resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Pool
+			return zeroValue, err
+		}
+		target.Connection = param0
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Pool
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Pool = value
+	d.singleton_github_com_dimes_dihedral_internal_example_concurrency_Pool_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,115 @@
+//go:generate dihedral -definition ConstructorsDefinition
+
+// Package constructors contains a component whose types are provided by constructor
+// functions rather than by module methods
+package constructors
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/dimes/dihedral/embeds"
+)
+
+// RetryPolicy configures how often requests are retried
+type RetryPolicy struct {
+	Attempts int
+}
+
+// NewRetryPolicy returns the default retry policy
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{Attempts: 3}
+}
+
+// Client sends requests to an endpoint
+type Client struct {
+	Endpoint *url.URL
+	Policy   *RetryPolicy
+}
+
+// NewClient returns a client for the given endpoint. The endpoint must be absolute.
+func NewClient(endpoint *url.URL, policy *RetryPolicy) (*Client, error) {
+	if !endpoint.IsAbs() {
+		return nil, fmt.Errorf("Endpoint %s is not absolute", endpoint)
+	}
+
+	return &Client{
+		Endpoint: endpoint,
+		Policy:   policy,
+	}, nil
+}
+
+// Pool holds the connections of a client. It is created by OpenPool, which is
+// named by the tag of the marker.
+type Pool struct {
+	inject embeds.Inject `di:"constructor=OpenPool"`
+
+	client *Client
+	size   int
+}
+
+// OpenPool opens a pool of connections of the client
+func OpenPool(client *Client) (*Pool, error) {
+	if client.Policy.Attempts <= 0 {
+		return nil, fmt.Errorf("Client has no attempts")
+	}
+
+	return &Pool{client: client, size: client.Policy.Attempts}, nil
+}
+
+// Size returns the number of connections in the pool
+func (p *Pool) Size() int {
+	return p.size
+}
+
+// Settings are injected field by field. NewSettings is not meant to be called by the
+// component, so it is skipped by the tag of the marker.
+type Settings struct {
+	inject embeds.Inject `di:"constructor=-"`
+
+	Policy *RetryPolicy
+}
+
+// NewSettings returns settings that retry the given number of times
+func NewSettings(attempts int) *Settings {
+	return &Settings{Policy: &RetryPolicy{Attempts: attempts}}
+}
+
+// Service uses the client
+type Service struct {
+	inject embeds.Inject
+	Client *Client
+}
+
+// ConfigModule provides the raw URL of the endpoint, which is parsed by url.Parse
+//
+//di:constructor url.Parse
+type ConfigModule struct {
+	provided embeds.ProvidedModule
+	URL      string
+}
+
+// ProvidesURL provides the raw URL of the endpoint
+func (c *ConfigModule) ProvidesURL() string {
+	return c.URL
+}
+
+// ClientModule lists the constructors of the client
+//
+//di:constructor NewClient
+//di:constructor NewRetryPolicy
+type ClientModule interface{}
+
+// ConstructorsDefinition defines the ConstructorsComponent
+type ConstructorsDefinition interface {
+	Modules() (*ConfigModule, ClientModule)
+	Target() ConstructorsComponent
+}
+
+// ConstructorsComponent returns the service, the client, the pool and the settings
+type ConstructorsComponent interface {
+	GetService() (*Service, error)
+	GetClient() (*Client, error)
+	GetPool() (*Pool, error)
+	GetSettings() (*Settings, error)
+}
@@ -0,0 +1,70 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/constructors"
+)
+
+type DihedralConstructorsComponent struct {
+	cleanups                                                             di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_constructors_ConfigModule *di_import_1.ConfigModule
+}
+
+func NewDihedralConstructorsComponent(
+	github_com_dimes_dihedral_internal_example_constructors_ConfigModule *di_import_1.ConfigModule,
+) *DihedralConstructorsComponent {
+	return &DihedralConstructorsComponent{
+		github_com_dimes_dihedral_internal_example_constructors_ConfigModule: github_com_dimes_dihedral_internal_example_constructors_ConfigModule,
+	}
+}
+func (d *DihedralConstructorsComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralConstructorsComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralConstructorsComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralConstructorsComponent) GetClient() (*di_import_1.Client, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Client, error) {
+		return d.provides_github_com_dimes_dihedral_internal_example_constructors_Client(resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Client
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralConstructorsComponent) GetPool() (*di_import_1.Pool, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Pool, error) {
+		return factory_github_com_dimes_dihedral_internal_example_constructors_Pool(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Pool
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralConstructorsComponent) GetService() (*di_import_1.Service, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Service, error) {
+		return factory_github_com_dimes_dihedral_internal_example_constructors_Service(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Service
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralConstructorsComponent) GetSettings() (*di_import_1.Settings, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Settings, error) {
+		return factory_github_com_dimes_dihedral_internal_example_constructors_Settings(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Settings
+		return zeroValue, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,25 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/constructors"
+)
+
+func (d *DihedralConstructorsComponent) provides_github_com_dimes_dihedral_internal_example_constructors_Client(resolution *di_import_2.Cleanups) (*target_pkg.Client, error) {
+	param0, err := d.provides_net_url_URL(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Client
+		return zeroValue, err
+	}
+	param1, err := d.provides_github_com_dimes_dihedral_internal_example_constructors_RetryPolicy(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Client
+		return zeroValue, err
+	}
+	returnValue, err := target_pkg.NewClient(
+		param0,
+		param1,
+	)
+	return returnValue, err
+}
@@ -0,0 +1,21 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/constructors"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_constructors_Pool(d *DihedralConstructorsComponent, resolution *di_import_2.Cleanups) (*target_pkg.Pool, error) {
+	param0, err := d.provides_github_com_dimes_dihedral_internal_example_constructors_Client(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Pool
+		return zeroValue, err
+	}
+	target, err := target_pkg.OpenPool(param0)
+	if err != nil {
+		var zeroValue *target_pkg.Pool
+		return zeroValue, err
+	}
+	return target, nil
+}
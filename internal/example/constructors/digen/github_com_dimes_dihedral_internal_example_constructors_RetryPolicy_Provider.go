@@ -0,0 +1,12 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/constructors"
+)
+
+func (d *DihedralConstructorsComponent) provides_github_com_dimes_dihedral_internal_example_constructors_RetryPolicy(resolution *di_import_2.Cleanups) (*target_pkg.RetryPolicy, error) {
+	returnValue := target_pkg.NewRetryPolicy()
+	return returnValue, nil
+}
@@ -0,0 +1,18 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/constructors"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_constructors_Settings(d *DihedralConstructorsComponent, resolution *di_import_2.Cleanups) (*target_pkg.Settings, error) {
+	target := &target_pkg.Settings{}
+	param0, err := d.provides_github_com_dimes_dihedral_internal_example_constructors_RetryPolicy(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Settings
+		return zeroValue, err
+	}
+	target.Policy = param0
+	return target, nil
+}
@@ -0,0 +1,19 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "net/url"
+)
+
+func (d *DihedralConstructorsComponent) provides_net_url_URL(resolution *di_import_2.Cleanups) (*target_pkg.URL, error) {
+	param0, err := d.provides_string(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.URL
+		return zeroValue, err
+	}
+	returnValue, err := target_pkg.Parse(
+		param0,
+	)
+	return returnValue, err
+}
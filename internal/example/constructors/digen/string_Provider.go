@@ -0,0 +1,11 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_1 "github.com/dimes/dihedral/inject"
+)
+
+func (d *DihedralConstructorsComponent) provides_string(resolution *di_import_1.Cleanups) (string, error) {
+	returnValue := d.github_com_dimes_dihedral_internal_example_constructors_ConfigModule.ProvidesURL()
+	return returnValue, nil
+}
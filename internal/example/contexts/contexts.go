@@ -0,0 +1,78 @@
+//go:generate dihedral -definition ContextsDefinition
+
+// Package contexts contains a component whose providers and Init hooks receive the
+// context passed to its targets
+package contexts
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dimes/dihedral/embeds"
+	"github.com/dimes/dihedral/inject"
+)
+
+type tenantKey struct{}
+
+// WithTenant returns a context that carries the given tenant
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// Tenant is the tenant a request is handled for
+type Tenant string
+
+// TenantModule provides the tenant from the context
+type TenantModule struct{}
+
+// ProvidesTenant reads the tenant from the context of the resolution
+func (t *TenantModule) ProvidesTenant(ctx context.Context) (Tenant, error) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	if !ok {
+		return "", errors.New("No tenant in context")
+	}
+
+	return Tenant(tenant), nil
+}
+
+// Connection is opened by its Init method, which fails once the context is done
+type Connection struct {
+	inject embeds.Inject
+	Tenant Tenant
+
+	opened bool
+}
+
+// Init opens the connection
+func (c *Connection) Init(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.opened = true
+	return nil
+}
+
+// Opened returns true if the connection was opened
+func (c *Connection) Opened() bool {
+	return c.opened
+}
+
+// Handler opens connections for the tenant of the context it was created with
+type Handler struct {
+	inject      embeds.Inject
+	Connection  *Connection
+	Connections inject.Provider[*Connection]
+}
+
+// ContextsDefinition defines the target and the modules to include
+type ContextsDefinition interface {
+	Modules() *TenantModule
+	Target() ContextsComponent
+}
+
+// ContextsComponent is the component under test
+type ContextsComponent interface {
+	GetHandler(ctx context.Context) (*Handler, error)
+	GetTenant() (Tenant, error)
+}
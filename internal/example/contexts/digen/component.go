@@ -0,0 +1,48 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/contexts"
+)
+
+type DihedralContextsComponent struct {
+	cleanups                                                         di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_contexts_TenantModule *di_import_1.TenantModule
+}
+
+func NewDihedralContextsComponent() *DihedralContextsComponent {
+	return &DihedralContextsComponent{
+		github_com_dimes_dihedral_internal_example_contexts_TenantModule: &di_import_1.TenantModule{},
+	}
+}
+func (d *DihedralContextsComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralContextsComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralContextsComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralContextsComponent) GetHandler(ctx context.Context) (*di_import_1.Handler, error) {
+	obj, err := di_import_2.Resolve(ctx, &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Handler, error) {
+		return factory_github_com_dimes_dihedral_internal_example_contexts_Handler(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Handler
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralContextsComponent) GetTenant() (di_import_1.Tenant, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (di_import_1.Tenant, error) {
+		return d.provides_github_com_dimes_dihedral_internal_example_contexts_Tenant(resolution)
+	})
+	if err != nil {
+		var zeroValue di_import_1.Tenant
+		return zeroValue, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,22 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/contexts"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_contexts_Connection(d *DihedralContextsComponent, resolution *di_import_2.Cleanups) (*target_pkg.Connection, error) {
+	target := &target_pkg.Connection{}
+	param0, err := d.provides_github_com_dimes_dihedral_internal_example_contexts_Tenant(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Connection
+		return zeroValue, err
+	}
+	target.Tenant = param0
+	if err := target.Init(resolution.Context()); err != nil {
+		var zeroValue *target_pkg.Connection
+		return zeroValue, err
+	}
+	return target, nil
+}
@@ -0,0 +1,28 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/contexts"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_contexts_Handler(d *DihedralContextsComponent, resolution *di_import_2.Cleanups) (*target_pkg.Handler, error) {
+	target := &target_pkg.Handler{}
+	param0, err := factory_github_com_dimes_dihedral_internal_example_contexts_Connection(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Handler
+		return zeroValue, err
+	}
+	target.Connection = param0
+	param1, err := di_import_2.Provider[*target_pkg.Connection](func() (*target_pkg.Connection, error) {
+		return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Connection, error) {
+			return factory_github_com_dimes_dihedral_internal_example_contexts_Connection(d, resolution)
+		})
+	}), error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Handler
+		return zeroValue, err
+	}
+	target.Connections = param1
+	return target, nil
+}
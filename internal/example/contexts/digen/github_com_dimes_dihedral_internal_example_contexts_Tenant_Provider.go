@@ -0,0 +1,19 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/contexts"
+)
+
+func (d *DihedralContextsComponent) provides_github_com_dimes_dihedral_internal_example_contexts_Tenant(resolution *di_import_2.Cleanups) (target_pkg.Tenant, error) {
+	param0, err := resolution.Context(), error(nil)
+	if err != nil {
+		var zeroValue target_pkg.Tenant
+		return zeroValue, err
+	}
+	returnValue, err := d.github_com_dimes_dihedral_internal_example_contexts_TenantModule.ProvidesTenant(
+		param0,
+	)
+	return returnValue, err
+}
@@ -13,12 +13,20 @@ type DBStore interface {
 // Prefix is a prefix to append to calls to GetString
 type Prefix string
 
-// MemoryDBStore is a DBStore implementation that writes to memory
+// MemoryDBStore is a DBStore implementation that writes to memory. Only
+// one instance is created per component.
 type MemoryDBStore struct {
-	inject embeds.Inject
-	Prefix Prefix
+	inject    embeds.Inject
+	singleton embeds.Singleton
 
-	value string
+	prefix Prefix
+	value  string
+}
+
+// NewMemoryDBStore returns a store with the given prefix. It is called by
+// the generated factory instead of assigning the fields of the store.
+func NewMemoryDBStore(prefix Prefix) *MemoryDBStore {
+	return &MemoryDBStore{prefix: prefix}
 }
 
 // StoreString stores a string in the table
@@ -29,5 +37,5 @@ func (m *MemoryDBStore) StoreString(value string) error {
 
 // GetString returns the in-memory string value
 func (m *MemoryDBStore) GetString() string {
-	return string(m.Prefix) + " " + m.value
+	return string(m.prefix) + " " + m.value
 }
@@ -1,6 +1,7 @@
 package example
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/dimes/dihedral/embeds"
@@ -27,3 +28,42 @@ func (s *Service) SetValueInDBStore(value string) error {
 func (s *Service) GetValueFromDBStore() string {
 	return s.DBStore.GetString()
 }
+
+// ApplicationScope is the scope of the top-level service component
+type ApplicationScope struct {
+	scope embeds.Scope // Mark this struct as a scope
+}
+
+// RequestScope is the scope of the components created for each request
+type RequestScope struct {
+	scope embeds.Scope
+}
+
+// RequestID identifies a single request handled by the service
+type RequestID string
+
+// RequestCounter counts the requests handled by the service. There is one
+// counter for the whole application.
+type RequestCounter struct {
+	inject embeds.Inject
+	scope  ApplicationScope
+
+	count int32
+}
+
+// Increment increments the number of handled requests and returns the new count
+func (r *RequestCounter) Increment() int32 {
+	return atomic.AddInt32(&r.count, 1)
+}
+
+// RequestHandler handles a single request. It is created by a request
+// subcomponent and mixes request-scoped values with values from the parent.
+type RequestHandler struct {
+	inject embeds.Inject
+	scope  RequestScope // One handler per request component
+
+	RequestID RequestID
+	Service   *Service
+	DBStore   dbstore.DBStore
+	Counter   *RequestCounter
+}
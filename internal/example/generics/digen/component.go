@@ -0,0 +1,71 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_3 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/generics"
+	di_import_2 "time"
+)
+
+type DihedralGenericsComponent struct {
+	cleanups                                                                                                                                          di_import_3.Cleanups
+	github_com_dimes_dihedral_internal_example_generics_CacheModule                                                                                   *di_import_1.CacheModule
+	singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_User_       *di_import_1.Cache[string, di_import_1.User]
+	singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_User__lock  di_import_3.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time_                                                      *di_import_1.Cache[string, di_import_2.Time]
+	singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time__lock                                                 di_import_3.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order_      *di_import_1.Cache[string, di_import_1.Order]
+	singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order__lock di_import_3.SingletonLock
+}
+
+func NewDihedralGenericsComponent() *DihedralGenericsComponent {
+	return &DihedralGenericsComponent{
+		github_com_dimes_dihedral_internal_example_generics_CacheModule: &di_import_1.CacheModule{},
+	}
+}
+func (d *DihedralGenericsComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralGenericsComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralGenericsComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralGenericsComponent) GetOrders() *di_import_1.Repository[di_import_1.Order] {
+	obj, err := di_import_3.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_3.Cleanups) (*di_import_1.Repository[di_import_1.Order], error) {
+		return factory_github_com_dimes_dihedral_internal_example_generics_Repository_of_github_com_dimes_dihedral_internal_example_generics_Order_(d, resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralGenericsComponent) GetSessions() *di_import_1.Cache[string, di_import_2.Time] {
+	obj, err := di_import_3.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_3.Cleanups) (*di_import_1.Cache[string, di_import_2.Time], error) {
+		return d.provides_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time_(resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralGenericsComponent) GetUserStore() di_import_1.Store[di_import_1.User] {
+	obj, err := di_import_3.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_3.Cleanups) (di_import_1.Store[di_import_1.User], error) {
+		return factory_github_com_dimes_dihedral_internal_example_generics_MemoryStore_of_github_com_dimes_dihedral_internal_example_generics_User_(d, resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralGenericsComponent) GetUsers() *di_import_1.Repository[di_import_1.User] {
+	obj, err := di_import_3.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_3.Cleanups) (*di_import_1.Repository[di_import_1.User], error) {
+		return factory_github_com_dimes_dihedral_internal_example_generics_Repository_of_github_com_dimes_dihedral_internal_example_generics_User_(d, resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
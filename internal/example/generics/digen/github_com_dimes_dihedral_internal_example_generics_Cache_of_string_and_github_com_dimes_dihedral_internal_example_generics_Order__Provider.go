@@ -0,0 +1,32 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/generics"
+)
+
+func (d *DihedralGenericsComponent) provides_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order_(resolution *di_import_2.Cleanups) (*target_pkg.Cache[string, target_pkg.Order], error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order__lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order_, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order__lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/generics.Cache[string,github.com/dimes/dihedral/internal/example/generics.Order]"); err != nil {
+		var zeroValue *target_pkg.Cache[string, target_pkg.Order]
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order__lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order__lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order_, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order__lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Cache[string, target_pkg.Order], error) {
+		returnValue := d.github_com_dimes_dihedral_internal_example_generics_CacheModule.ProvidesOrderCache()
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Cache[string, target_pkg.Order]
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order_ = value
+	d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_github_com_dimes_dihedral_internal_example_generics_Order__lock.MarkDone()
+	return value, nil
+}
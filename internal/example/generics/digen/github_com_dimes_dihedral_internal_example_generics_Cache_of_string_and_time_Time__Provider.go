@@ -0,0 +1,33 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_3 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/generics"
+	di_import_2 "time"
+)
+
+func (d *DihedralGenericsComponent) provides_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time_(resolution *di_import_3.Cleanups) (*target_pkg.Cache[string, di_import_2.Time], error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time__lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time_, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time__lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/generics.Cache[string,time.Time]"); err != nil {
+		var zeroValue *target_pkg.Cache[string, di_import_2.Time]
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time__lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time__lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time_, nil
+	}
+	value, err := di_import_3.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time__lock.Creating(resolution), &d.cleanups, func(resolution *di_import_3.Cleanups) (*target_pkg.Cache[string, di_import_2.Time], error) {
+		returnValue := d.github_com_dimes_dihedral_internal_example_generics_CacheModule.ProvidesSessionCache()
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Cache[string, di_import_2.Time]
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time_ = value
+	d.singleton_github_com_dimes_dihedral_internal_example_generics_Cache_of_string_and_time_Time__lock.MarkDone()
+	return value, nil
+}
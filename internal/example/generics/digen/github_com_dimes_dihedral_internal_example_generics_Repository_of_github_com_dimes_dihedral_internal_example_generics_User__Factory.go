@@ -0,0 +1,18 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/generics"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_generics_Repository_of_github_com_dimes_dihedral_internal_example_generics_User_(d *DihedralGenericsComponent, resolution *di_import_2.Cleanups) (*target_pkg.Repository[target_pkg.User], error) {
+	target := &target_pkg.Repository[target_pkg.User]{}
+	param0, err := factory_github_com_dimes_dihedral_internal_example_generics_MemoryStore_of_github_com_dimes_dihedral_internal_example_generics_User_(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Repository[target_pkg.User]
+		return zeroValue, err
+	}
+	target.Store = param0
+	return target, nil
+}
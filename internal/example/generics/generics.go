@@ -0,0 +1,94 @@
+//go:generate dihedral -definition GenericsDefinition
+
+// Package generics contains a component that injects instantiated generic types
+package generics
+
+import (
+	"time"
+
+	"github.com/dimes/dihedral/embeds"
+)
+
+// User is stored in the user store
+type User struct {
+	Name string
+}
+
+// Order is stored in the order store
+type Order struct {
+	ID int
+}
+
+// Cache holds values by key
+type Cache[K comparable, V any] struct {
+	Values map[K]V
+}
+
+// Store stores values of a single type
+type Store[T any] interface {
+	Save(key string, value T)
+	Load(key string) (T, bool)
+}
+
+// MemoryStore stores values in a cache
+type MemoryStore[T any] struct {
+	inject embeds.Inject
+	Cache  *Cache[string, T]
+}
+
+// Save saves the value with the given key
+func (m *MemoryStore[T]) Save(key string, value T) {
+	m.Cache.Values[key] = value
+}
+
+// Load returns the value with the given key
+func (m *MemoryStore[T]) Load(key string) (T, bool) {
+	value, ok := m.Cache.Values[key]
+	return value, ok
+}
+
+// Repository is an injectable generic struct
+type Repository[T any] struct {
+	inject embeds.Inject
+	Store  Store[T]
+}
+
+// CacheModule provides the caches
+type CacheModule struct {
+	singleton embeds.Singleton
+}
+
+// ProvidesUserCache provides the cache of the users
+func (c *CacheModule) ProvidesUserCache() *Cache[string, User] {
+	return &Cache[string, User]{Values: make(map[string]User)}
+}
+
+// ProvidesOrderCache provides the cache of the orders
+func (c *CacheModule) ProvidesOrderCache() *Cache[string, Order] {
+	return &Cache[string, Order]{Values: make(map[string]Order)}
+}
+
+// ProvidesSessionCache provides the cache of the session expirations
+func (c *CacheModule) ProvidesSessionCache() *Cache[string, time.Time] {
+	return &Cache[string, time.Time]{Values: make(map[string]time.Time)}
+}
+
+// StoreModule binds the stores
+type StoreModule interface {
+	BindsUserStore(impl *MemoryStore[User]) Store[User]
+	BindsOrderStore(impl *MemoryStore[Order]) Store[Order]
+}
+
+// GenericsDefinition defines the GenericsComponent
+type GenericsDefinition interface {
+	Modules() (*CacheModule, StoreModule)
+	Target() GenericsComponent
+}
+
+// GenericsComponent returns the repositories
+type GenericsComponent interface {
+	GetUsers() *Repository[User]
+	GetOrders() *Repository[Order]
+	GetUserStore() Store[User]
+	GetSessions() *Cache[string, time.Time]
+}
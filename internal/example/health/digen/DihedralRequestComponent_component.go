@@ -0,0 +1,34 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/health"
+)
+
+type DihedralRequestComponent struct {
+	parent                                                          *DihedralHealthComponent
+	cleanups                                                        di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_health_RequestModule *di_import_1.RequestModule
+}
+
+func (d *DihedralRequestComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralRequestComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralRequestComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralRequestComponent) GetHealthService() (*di_import_1.HealthService, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.HealthService, error) {
+		return factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_health_HealthService(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.HealthService
+		return zeroValue, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,18 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/health"
+)
+
+func factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_health_HealthService(d *DihedralRequestComponent, resolution *di_import_2.Cleanups) (*target_pkg.HealthService, error) {
+	target := &target_pkg.HealthService{}
+	param0, err := multibinds_DihedralRequestComponent_slice_github_com_dimes_dihedral_internal_example_health_Check(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.HealthService
+		return zeroValue, err
+	}
+	target.Checks = param0
+	return target, nil
+}
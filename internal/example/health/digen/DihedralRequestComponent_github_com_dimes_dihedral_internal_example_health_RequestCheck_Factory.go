@@ -0,0 +1,18 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/health"
+)
+
+func factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_health_RequestCheck(d *DihedralRequestComponent, resolution *di_import_2.Cleanups) (*target_pkg.RequestCheck, error) {
+	target := &target_pkg.RequestCheck{}
+	param0, err := d.provides_github_com_dimes_dihedral_internal_example_health_RequestID(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.RequestCheck
+		return zeroValue, err
+	}
+	target.RequestID = param0
+	return target, nil
+}
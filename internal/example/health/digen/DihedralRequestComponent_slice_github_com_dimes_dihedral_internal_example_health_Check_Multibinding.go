@@ -0,0 +1,27 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_1 "github.com/dimes/dihedral/inject"
+	di_import_2 "github.com/dimes/dihedral/internal/example/health"
+)
+
+func multibinds_DihedralRequestComponent_slice_github_com_dimes_dihedral_internal_example_health_Check(d *DihedralRequestComponent, resolution *di_import_1.Cleanups) ([]di_import_2.Check, error) {
+	elements := make([]di_import_2.Check, 0, 3)
+	element0, err := factory_github_com_dimes_dihedral_internal_example_health_DatabaseCheck(d.parent, resolution)
+	if err != nil {
+		return nil, err
+	}
+	elements = append(elements, (di_import_2.Check)(element0))
+	element1, err := d.parent.provides_github_com_dimes_dihedral_internal_example_health_CheckProviderModule_ProvidesUptimeCheck(resolution)
+	if err != nil {
+		return nil, err
+	}
+	elements = append(elements, (di_import_2.Check)(element1))
+	element2, err := factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_health_RequestCheck(d, resolution)
+	if err != nil {
+		return nil, err
+	}
+	elements = append(elements, (di_import_2.Check)(element2))
+	return elements, nil
+}
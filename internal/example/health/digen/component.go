@@ -0,0 +1,56 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/health"
+)
+
+type DihedralHealthComponent struct {
+	cleanups                                                              di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_health_CheckProviderModule *di_import_1.CheckProviderModule
+}
+
+func NewDihedralHealthComponent() *DihedralHealthComponent {
+	return &DihedralHealthComponent{
+		github_com_dimes_dihedral_internal_example_health_CheckProviderModule: &di_import_1.CheckProviderModule{},
+	}
+}
+func (d *DihedralHealthComponent) NewRequestComponent(
+	github_com_dimes_dihedral_internal_example_health_RequestModule *di_import_1.RequestModule,
+) di_import_1.RequestComponent {
+	return &DihedralRequestComponent{
+		parent: d,
+		github_com_dimes_dihedral_internal_example_health_RequestModule: github_com_dimes_dihedral_internal_example_health_RequestModule,
+	}
+}
+func (d *DihedralHealthComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralHealthComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralHealthComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralHealthComponent) GetChecks() ([]di_import_1.Check, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) ([]di_import_1.Check, error) {
+		return multibinds_slice_github_com_dimes_dihedral_internal_example_health_Check(d, resolution)
+	})
+	if err != nil {
+		var zeroValue []di_import_1.Check
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralHealthComponent) GetHealthService() (*di_import_1.HealthService, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.HealthService, error) {
+		return factory_github_com_dimes_dihedral_internal_example_health_HealthService(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.HealthService
+		return zeroValue, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,22 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_1 "github.com/dimes/dihedral/inject"
+	di_import_2 "github.com/dimes/dihedral/internal/example/health"
+)
+
+func multibinds_slice_github_com_dimes_dihedral_internal_example_health_Check(d *DihedralHealthComponent, resolution *di_import_1.Cleanups) ([]di_import_2.Check, error) {
+	elements := make([]di_import_2.Check, 0, 2)
+	element0, err := factory_github_com_dimes_dihedral_internal_example_health_DatabaseCheck(d, resolution)
+	if err != nil {
+		return nil, err
+	}
+	elements = append(elements, (di_import_2.Check)(element0))
+	element1, err := d.provides_github_com_dimes_dihedral_internal_example_health_CheckProviderModule_ProvidesUptimeCheck(resolution)
+	if err != nil {
+		return nil, err
+	}
+	elements = append(elements, (di_import_2.Check)(element1))
+	return elements, nil
+}
@@ -0,0 +1,119 @@
+//go:generate dihedral -definition HealthDefinition
+
+// Package health contains a component that collects health checks from several
+// modules into a slice
+package health
+
+import (
+	"github.com/dimes/dihedral/embeds"
+)
+
+// Check is a single health check
+type Check interface {
+	Name() string
+}
+
+// DatabaseCheck is bound into the checks by a binding module
+type DatabaseCheck struct {
+	inject embeds.Inject
+}
+
+// Name returns the name of the check
+func (d *DatabaseCheck) Name() string {
+	return "database"
+}
+
+// UptimeCheck is provided into the checks by a provider module
+type UptimeCheck struct{}
+
+// Name returns the name of the check
+func (u *UptimeCheck) Name() string {
+	return "uptime"
+}
+
+// RequestCheck is contributed by the request subcomponent
+type RequestCheck struct {
+	inject    embeds.Inject
+	RequestID RequestID
+}
+
+// Name returns the name of the check
+func (r *RequestCheck) Name() string {
+	return "request " + string(r.RequestID)
+}
+
+// RequestID identifies the request of a request component
+type RequestID string
+
+// HealthService runs all checks
+type HealthService struct {
+	inject embeds.Inject
+	Checks []Check
+}
+
+// Names returns the names of all checks
+func (h *HealthService) Names() []string {
+	names := make([]string, 0, len(h.Checks))
+	for _, check := range h.Checks {
+		names = append(names, check.Name())
+	}
+
+	return names
+}
+
+// CheckBindingModule binds the DatabaseCheck into the checks
+type CheckBindingModule interface {
+	//di:set
+	BindsDatabaseCheck(impl *DatabaseCheck) Check
+}
+
+// CheckProviderModule provides the UptimeCheck into the checks
+type CheckProviderModule struct{}
+
+// ProvidesUptimeCheck provides the uptime check
+//
+//di:set
+func (c *CheckProviderModule) ProvidesUptimeCheck() Check {
+	return &UptimeCheck{}
+}
+
+// HealthDefinition defines the HealthComponent
+type HealthDefinition interface {
+	Modules() (CheckBindingModule, *CheckProviderModule, RequestDefinition)
+	Target() HealthComponent
+}
+
+// HealthComponent returns the health service and all checks
+type HealthComponent interface {
+	GetHealthService() (*HealthService, error)
+	GetChecks() ([]Check, error)
+	NewRequestComponent(module *RequestModule) RequestComponent
+}
+
+// RequestModule provides the request ID and adds a check for the request
+type RequestModule struct {
+	provided  embeds.ProvidedModule
+	RequestID RequestID
+}
+
+// ProvidesRequestID provides the ID of the request
+func (r *RequestModule) ProvidesRequestID() RequestID {
+	return r.RequestID
+}
+
+// RequestCheckModule binds the RequestCheck into the checks
+type RequestCheckModule interface {
+	//di:set
+	BindsRequestCheck(impl *RequestCheck) Check
+}
+
+// RequestDefinition defines a subcomponent whose checks include the checks of the parent
+type RequestDefinition interface {
+	Modules() (*RequestModule, RequestCheckModule)
+	Target() RequestComponent
+}
+
+// RequestComponent returns the health service of a request
+type RequestComponent interface {
+	GetHealthService() (*HealthService, error)
+}
@@ -0,0 +1,48 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_3 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/injectables"
+	di_import_2 "github.com/dimes/dihedral/internal/example/injectables/lib"
+)
+
+type DihedralInjectablesComponent struct {
+	cleanups                                                               di_import_3.Cleanups
+	github_com_dimes_dihedral_internal_example_injectables_TransportModule *di_import_1.TransportModule
+}
+
+func NewDihedralInjectablesComponent() *DihedralInjectablesComponent {
+	return &DihedralInjectablesComponent{
+		github_com_dimes_dihedral_internal_example_injectables_TransportModule: &di_import_1.TransportModule{},
+	}
+}
+func (d *DihedralInjectablesComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralInjectablesComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralInjectablesComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralInjectablesComponent) GetApp() *di_import_1.App {
+	obj, err := di_import_3.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_3.Cleanups) (*di_import_1.App, error) {
+		return factory_github_com_dimes_dihedral_internal_example_injectables_App(d, resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralInjectablesComponent) GetClient() (*di_import_2.Client, error) {
+	obj, err := di_import_3.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_3.Cleanups) (*di_import_2.Client, error) {
+		return factory_github_com_dimes_dihedral_internal_example_injectables_lib_Client(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_2.Client
+		return zeroValue, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,18 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/injectables"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_injectables_App(d *DihedralInjectablesComponent, resolution *di_import_2.Cleanups) (*target_pkg.App, error) {
+	target := &target_pkg.App{}
+	param0, err := factory_github_com_dimes_dihedral_internal_example_injectables_lib_Server(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.App
+		return zeroValue, err
+	}
+	target.Server = param0
+	return target, nil
+}
@@ -0,0 +1,24 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/injectables/lib"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_injectables_lib_Client(d *DihedralInjectablesComponent, resolution *di_import_2.Cleanups) (*target_pkg.Client, error) {
+	target := &target_pkg.Client{}
+	param0, err := d.provides_github_com_dimes_dihedral_internal_example_injectables_lib_Transport_name_primary(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Client
+		return zeroValue, err
+	}
+	target.Transport = param0
+	param1, err := factory_github_com_dimes_dihedral_internal_example_injectables_MemoryLogger(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Client
+		return zeroValue, err
+	}
+	target.Logger = param1
+	return target, nil
+}
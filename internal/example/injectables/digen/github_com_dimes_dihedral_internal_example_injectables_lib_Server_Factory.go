@@ -0,0 +1,18 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/injectables/lib"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_injectables_lib_Server(d *DihedralInjectablesComponent, resolution *di_import_2.Cleanups) (*target_pkg.Server, error) {
+	target := &target_pkg.Server{}
+	param0, err := factory_github_com_dimes_dihedral_internal_example_injectables_lib_Client(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Server
+		return zeroValue, err
+	}
+	target.Client = param0
+	return target, nil
+}
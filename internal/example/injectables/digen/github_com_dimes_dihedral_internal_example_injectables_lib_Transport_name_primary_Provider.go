@@ -0,0 +1,12 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/injectables/lib"
+)
+
+func (d *DihedralInjectablesComponent) provides_github_com_dimes_dihedral_internal_example_injectables_lib_Transport_name_primary(resolution *di_import_2.Cleanups) (*target_pkg.Transport, error) {
+	returnValue := d.github_com_dimes_dihedral_internal_example_injectables_TransportModule.ProvidesPrimaryTransport()
+	return returnValue, nil
+}
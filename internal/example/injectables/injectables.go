@@ -0,0 +1,69 @@
+//go:generate dihedral -definition InjectablesDefinition
+
+// Package injectables contains a component that injects structs of a library,
+// which are declared injectable by a binding module
+package injectables
+
+import (
+	"time"
+
+	"github.com/dimes/dihedral/embeds"
+	"github.com/dimes/dihedral/internal/example/injectables/lib"
+)
+
+// MemoryLogger keeps the logged messages in memory
+type MemoryLogger struct {
+	inject   embeds.Inject
+	messages []string
+}
+
+// Log appends the message to the messages
+func (m *MemoryLogger) Log(message string) {
+	m.messages = append(m.messages, message)
+}
+
+// Messages returns the logged messages
+func (m *MemoryLogger) Messages() []string {
+	return m.messages
+}
+
+// ClientFields overrides the tags of the fields of lib.Client
+type ClientFields struct {
+	Transport *lib.Transport `di:"name=primary"`
+	Timeout   time.Duration  `di:"-"`
+}
+
+// App runs the server of the library
+type App struct {
+	inject embeds.Inject
+	Server *lib.Server
+}
+
+// TransportModule provides the transports
+type TransportModule struct{}
+
+// ProvidesPrimaryTransport provides the primary transport
+//
+//di:name primary
+func (t *TransportModule) ProvidesPrimaryTransport() *lib.Transport {
+	return &lib.Transport{Name: "primary"}
+}
+
+// LibModule declares the structs of the library injectable
+type LibModule interface {
+	InjectsClient(fields ClientFields) *lib.Client
+	InjectsServer() *lib.Server
+	BindsLogger(impl *MemoryLogger) lib.Logger
+}
+
+// InjectablesDefinition defines the InjectablesComponent
+type InjectablesDefinition interface {
+	Modules() (*TransportModule, LibModule)
+	Target() InjectablesComponent
+}
+
+// InjectablesComponent returns the app and the client
+type InjectablesComponent interface {
+	GetApp() *App
+	GetClient() (*lib.Client, error)
+}
@@ -0,0 +1,30 @@
+// Package lib is a library that does not depend on dihedral, so its structs
+// cannot be marked with embeds.Inject
+package lib
+
+import (
+	"time"
+)
+
+// Logger logs messages
+type Logger interface {
+	Log(message string)
+}
+
+// Transport sends requests
+type Transport struct {
+	Name string
+}
+
+// Client sends requests with a transport
+type Client struct {
+	Transport *Transport
+	Logger    Logger
+	Timeout   time.Duration
+	retries   int
+}
+
+// Server serves requests with a client
+type Server struct {
+	Client *Client
+}
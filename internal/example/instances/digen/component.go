@@ -0,0 +1,60 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_3 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/instances"
+	di_import_2 "log"
+)
+
+type DihedralInstancesComponent struct {
+	cleanups                                                                   di_import_3.Cleanups
+	github_com_dimes_dihedral_internal_example_instances_ConfigModule          *di_import_1.ConfigModule
+	instance_github_com_dimes_dihedral_internal_example_instances_Config       di_import_1.Config
+	instance_log_Logger                                                        *di_import_2.Logger
+	instance_slice_string                                                      []string
+	singleton_github_com_dimes_dihedral_internal_example_instances_Server      *di_import_1.Server
+	singleton_github_com_dimes_dihedral_internal_example_instances_Server_lock di_import_3.SingletonLock
+}
+
+func NewDihedralInstancesComponent(
+	instance_github_com_dimes_dihedral_internal_example_instances_Config di_import_1.Config,
+	instance_log_Logger *di_import_2.Logger,
+	instance_slice_string []string,
+) *DihedralInstancesComponent {
+	return &DihedralInstancesComponent{
+		github_com_dimes_dihedral_internal_example_instances_ConfigModule:    &di_import_1.ConfigModule{},
+		instance_github_com_dimes_dihedral_internal_example_instances_Config: instance_github_com_dimes_dihedral_internal_example_instances_Config,
+		instance_log_Logger:   instance_log_Logger,
+		instance_slice_string: instance_slice_string,
+	}
+}
+func (d *DihedralInstancesComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralInstancesComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralInstancesComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralInstancesComponent) GetLogger() *di_import_2.Logger {
+	obj, err := di_import_3.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_3.Cleanups) (*di_import_2.Logger, error) {
+		return d.instance_log_Logger, error(nil)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralInstancesComponent) GetServer() (*di_import_1.Server, error) {
+	obj, err := di_import_3.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_3.Cleanups) (*di_import_1.Server, error) {
+		return factory_github_com_dimes_dihedral_internal_example_instances_Server(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Server
+		return zeroValue, err
+	}
+	return obj, nil
+}
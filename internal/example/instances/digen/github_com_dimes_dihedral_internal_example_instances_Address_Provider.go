@@ -0,0 +1,19 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/instances"
+)
+
+func (d *DihedralInstancesComponent) provides_github_com_dimes_dihedral_internal_example_instances_Address(resolution *di_import_2.Cleanups) (target_pkg.Address, error) {
+	param0, err := d.instance_github_com_dimes_dihedral_internal_example_instances_Config, error(nil)
+	if err != nil {
+		var zeroValue target_pkg.Address
+		return zeroValue, err
+	}
+	returnValue := d.github_com_dimes_dihedral_internal_example_instances_ConfigModule.ProvidesAddress(
+		param0,
+	)
+	return returnValue, nil
+}
@@ -0,0 +1,56 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/instances"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_instances_Server(d *DihedralInstancesComponent, resolution *di_import_2.Cleanups) (*target_pkg.Server, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_instances_Server_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_instances_Server, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_instances_Server_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/instances.Server"); err != nil {
+		var zeroValue *target_pkg.Server
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_instances_Server_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_instances_Server_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_instances_Server, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_instances_Server_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Server, error) {
+		target := &target_pkg.Server{}
+		param0, err := d.instance_github_com_dimes_dihedral_internal_example_instances_Config, error(nil)
+		if err != nil {
+			var zeroValue *target_pkg.Server
+			return zeroValue, err
+		}
+		target.Config = param0
+		param1, err := d.provides_github_com_dimes_dihedral_internal_example_instances_Address(resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Server
+			return zeroValue, err
+		}
+		target.Address = param1
+		param2, err := d.instance_log_Logger, error(nil)
+		if err != nil {
+			var zeroValue *target_pkg.Server
+			return zeroValue, err
+		}
+		target.Logger = param2
+		param3, err := d.instance_slice_string, error(nil)
+		if err != nil {
+			var zeroValue *target_pkg.Server
+			return zeroValue, err
+		}
+		target.Hosts = param3
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Server
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_instances_Server = value
+	d.singleton_github_com_dimes_dihedral_internal_example_instances_Server_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,51 @@
+//go:generate dihedral -definition InstancesDefinition
+
+// Package instances contains a component whose constructor takes instances that are
+// injected without a module
+package instances
+
+import (
+	"log"
+
+	"github.com/dimes/dihedral/embeds"
+)
+
+// Config is passed to the constructor of the component
+type Config struct {
+	Address string
+	Hosts   []string
+}
+
+// Address is the address the server listens on
+type Address string
+
+// ConfigModule provides values from the config
+type ConfigModule struct{}
+
+// ProvidesAddress reads the address from the config
+func (c *ConfigModule) ProvidesAddress(config Config) Address {
+	return Address(config.Address)
+}
+
+// Server is created from the instances passed to the component
+type Server struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+	Config    Config
+	Address   Address
+	Logger    *log.Logger
+	Hosts     []string
+}
+
+// InstancesDefinition defines the target, the modules and the instances to include
+type InstancesDefinition interface {
+	Modules() *ConfigModule
+	Instances() (Config, *log.Logger, []string)
+	Target() InstancesComponent
+}
+
+// InstancesComponent is the component under test
+type InstancesComponent interface {
+	GetServer() (*Server, error)
+	GetLogger() *log.Logger
+}
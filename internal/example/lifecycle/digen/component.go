@@ -0,0 +1,101 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+type DihedralLifecycleComponent struct {
+	cleanups                                                                     di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_lifecycle_SessionModule           *di_import_1.SessionModule
+	github_com_dimes_dihedral_internal_example_lifecycle_ConfigModule            *di_import_1.ConfigModule
+	github_com_dimes_dihedral_internal_example_lifecycle_ListenerModule          *di_import_1.ListenerModule
+	singleton_github_com_dimes_dihedral_internal_example_lifecycle_Store         *di_import_1.Store
+	singleton_github_com_dimes_dihedral_internal_example_lifecycle_Store_lock    di_import_2.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_lifecycle_Listener      *di_import_1.Listener
+	singleton_github_com_dimes_dihedral_internal_example_lifecycle_Listener_lock di_import_2.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_lifecycle_Log           *di_import_1.Log
+	singleton_github_com_dimes_dihedral_internal_example_lifecycle_Log_lock      di_import_2.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_lifecycle_Database      *di_import_1.Database
+	singleton_github_com_dimes_dihedral_internal_example_lifecycle_Database_lock di_import_2.SingletonLock
+}
+
+func NewDihedralLifecycleComponent(
+	github_com_dimes_dihedral_internal_example_lifecycle_ConfigModule *di_import_1.ConfigModule,
+) *DihedralLifecycleComponent {
+	return &DihedralLifecycleComponent{
+		github_com_dimes_dihedral_internal_example_lifecycle_SessionModule:  &di_import_1.SessionModule{},
+		github_com_dimes_dihedral_internal_example_lifecycle_ConfigModule:   github_com_dimes_dihedral_internal_example_lifecycle_ConfigModule,
+		github_com_dimes_dihedral_internal_example_lifecycle_ListenerModule: &di_import_1.ListenerModule{},
+	}
+}
+func (d *DihedralLifecycleComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralLifecycleComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralLifecycleComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralLifecycleComponent) GetLog() *di_import_1.Log {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Log, error) {
+		return factory_github_com_dimes_dihedral_internal_example_lifecycle_Log(d, resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralLifecycleComponent) GetService() (*di_import_1.Service, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Service, error) {
+		return factory_github_com_dimes_dihedral_internal_example_lifecycle_Service(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Service
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralLifecycleComponent) GetStore() (*di_import_1.Store, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Store, error) {
+		return d.provides_github_com_dimes_dihedral_internal_example_lifecycle_Store(resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Store
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralLifecycleComponent) GetTransfer() (*di_import_1.Transfer, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Transfer, error) {
+		return factory_github_com_dimes_dihedral_internal_example_lifecycle_Transfer(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Transfer
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralLifecycleComponent) GetWorker() (*di_import_1.Worker, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Worker, error) {
+		return factory_github_com_dimes_dihedral_internal_example_lifecycle_Worker(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Worker
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralLifecycleComponent) OpenWorker() (*di_import_1.Worker, func() error, error) {
+	obj, cleanup, err := di_import_2.ResolveWithCleanup(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Worker, error) {
+		return factory_github_com_dimes_dihedral_internal_example_lifecycle_Worker(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Worker
+		return zeroValue, nil, err
+	}
+	return obj, cleanup, nil
+}
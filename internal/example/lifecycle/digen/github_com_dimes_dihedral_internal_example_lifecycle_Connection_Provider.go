@@ -0,0 +1,19 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+func (d *DihedralLifecycleComponent) provides_github_com_dimes_dihedral_internal_example_lifecycle_Connection(resolution *di_import_2.Cleanups) (*target_pkg.Connection, error) {
+	param0, err := d.provides_github_com_dimes_dihedral_internal_example_lifecycle_Config(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Connection
+		return zeroValue, err
+	}
+	returnValue, err := d.github_com_dimes_dihedral_internal_example_lifecycle_SessionModule.ProvidesConnection(
+		param0,
+	)
+	return returnValue, err
+}
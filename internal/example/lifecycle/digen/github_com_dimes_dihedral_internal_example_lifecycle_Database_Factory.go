@@ -0,0 +1,49 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_lifecycle_Database(d *DihedralLifecycleComponent, resolution *di_import_2.Cleanups) (*target_pkg.Database, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Database_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Database, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Database_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/lifecycle.Database"); err != nil {
+		var zeroValue *target_pkg.Database
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Database_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Database_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Database, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Database_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Database, error) {
+		target := &target_pkg.Database{}
+		param0, err := d.provides_github_com_dimes_dihedral_internal_example_lifecycle_Config(resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Database
+			return zeroValue, err
+		}
+		target.Config = param0
+		param1, err := factory_github_com_dimes_dihedral_internal_example_lifecycle_Log(d, resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Database
+			return zeroValue, err
+		}
+		target.Log = param1
+		if err := target.Init(); err != nil {
+			var zeroValue *target_pkg.Database
+			return zeroValue, err
+		}
+		resolution.Add(target.Close)
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Database
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Database = value
+	d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Database_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,26 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+func (d *DihedralLifecycleComponent) provides_github_com_dimes_dihedral_internal_example_lifecycle_Lease(resolution *di_import_2.Cleanups) (*target_pkg.Lease, error) {
+	param0, err := d.provides_github_com_dimes_dihedral_internal_example_lifecycle_Config(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Lease
+		return zeroValue, err
+	}
+	param1, err := factory_github_com_dimes_dihedral_internal_example_lifecycle_Log(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Lease
+		return zeroValue, err
+	}
+	returnValue, cleanup := d.github_com_dimes_dihedral_internal_example_lifecycle_SessionModule.ProvidesLease(
+		param0,
+		param1,
+	)
+	resolution.Add(cleanup)
+	return returnValue, nil
+}
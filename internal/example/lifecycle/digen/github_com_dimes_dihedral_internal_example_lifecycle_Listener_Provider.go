@@ -0,0 +1,50 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+func (d *DihedralLifecycleComponent) provides_github_com_dimes_dihedral_internal_example_lifecycle_Listener(resolution *di_import_2.Cleanups) (*target_pkg.Listener, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Listener_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Listener, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Listener_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/lifecycle.Listener"); err != nil {
+		var zeroValue *target_pkg.Listener
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Listener_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Listener_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Listener, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Listener_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Listener, error) {
+		param0, err := d.provides_github_com_dimes_dihedral_internal_example_lifecycle_Config(resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Listener
+			return zeroValue, err
+		}
+		param1, err := factory_github_com_dimes_dihedral_internal_example_lifecycle_Log(d, resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Listener
+			return zeroValue, err
+		}
+		returnValue, cleanup, err := d.github_com_dimes_dihedral_internal_example_lifecycle_ListenerModule.ProvidesListener(
+			param0,
+			param1,
+		)
+		if err != nil {
+			var zeroValue *target_pkg.Listener
+			return zeroValue, err
+		}
+		resolution.Add(cleanup)
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Listener
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Listener = value
+	d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Listener_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,32 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_lifecycle_Log(d *DihedralLifecycleComponent, resolution *di_import_2.Cleanups) (*target_pkg.Log, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Log_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Log, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Log_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/lifecycle.Log"); err != nil {
+		var zeroValue *target_pkg.Log
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Log_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Log_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Log, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Log_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Log, error) {
+		target := &target_pkg.Log{}
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Log
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Log = value
+	d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Log_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,34 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_lifecycle_Service(d *DihedralLifecycleComponent, resolution *di_import_2.Cleanups) (*target_pkg.Service, error) {
+	target := &target_pkg.Service{}
+	param0, err := factory_github_com_dimes_dihedral_internal_example_lifecycle_Database(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Service
+		return zeroValue, err
+	}
+	target.Database = param0
+	param1, err := d.provides_github_com_dimes_dihedral_internal_example_lifecycle_Listener(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Service
+		return zeroValue, err
+	}
+	target.Listener = param1
+	param2, err := factory_github_com_dimes_dihedral_internal_example_lifecycle_Log(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Service
+		return zeroValue, err
+	}
+	target.Log = param2
+	if err := target.Init(); err != nil {
+		var zeroValue *target_pkg.Service
+		return zeroValue, err
+	}
+	return target, nil
+}
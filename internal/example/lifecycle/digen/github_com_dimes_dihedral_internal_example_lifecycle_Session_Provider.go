@@ -0,0 +1,20 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+func (d *DihedralLifecycleComponent) provides_github_com_dimes_dihedral_internal_example_lifecycle_Session(resolution *di_import_2.Cleanups) (*target_pkg.Session, error) {
+	param0, err := factory_github_com_dimes_dihedral_internal_example_lifecycle_Log(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Session
+		return zeroValue, err
+	}
+	returnValue, cleanup := d.github_com_dimes_dihedral_internal_example_lifecycle_SessionModule.ProvidesSession(
+		param0,
+	)
+	resolution.AddFunc(cleanup)
+	return returnValue, nil
+}
@@ -0,0 +1,42 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+func (d *DihedralLifecycleComponent) provides_github_com_dimes_dihedral_internal_example_lifecycle_Store(resolution *di_import_2.Cleanups) (*target_pkg.Store, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Store_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Store, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Store_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/lifecycle.Store"); err != nil {
+		var zeroValue *target_pkg.Store
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Store_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Store_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Store, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Store_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Store, error) {
+		param0, err := factory_github_com_dimes_dihedral_internal_example_lifecycle_Log(d, resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Store
+			return zeroValue, err
+		}
+		returnValue := d.github_com_dimes_dihedral_internal_example_lifecycle_ListenerModule.ProvidesStore(
+			param0,
+		)
+		if returnValue != nil {
+			resolution.Add(returnValue.Close)
+		}
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Store
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Store = value
+	d.singleton_github_com_dimes_dihedral_internal_example_lifecycle_Store_lock.MarkDone()
+	return value, nil
+}
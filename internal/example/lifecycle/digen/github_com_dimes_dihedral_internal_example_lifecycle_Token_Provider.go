@@ -0,0 +1,13 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+func (d *DihedralLifecycleComponent) provides_github_com_dimes_dihedral_internal_example_lifecycle_Token(resolution *di_import_2.Cleanups) (*target_pkg.Token, error) {
+	returnValue, cleanup := d.github_com_dimes_dihedral_internal_example_lifecycle_SessionModule.ProvidesToken()
+	resolution.AddFunc(cleanup)
+	return returnValue, nil
+}
@@ -0,0 +1,30 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_lifecycle_Transfer(d *DihedralLifecycleComponent, resolution *di_import_2.Cleanups) (*target_pkg.Transfer, error) {
+	target := &target_pkg.Transfer{}
+	param0, err := d.provides_github_com_dimes_dihedral_internal_example_lifecycle_Lease(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Transfer
+		return zeroValue, err
+	}
+	target.Lease = param0
+	param1, err := d.provides_github_com_dimes_dihedral_internal_example_lifecycle_Token(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Transfer
+		return zeroValue, err
+	}
+	target.Token = param1
+	param2, err := d.provides_github_com_dimes_dihedral_internal_example_lifecycle_Connection(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Transfer
+		return zeroValue, err
+	}
+	target.Connection = param2
+	return target, nil
+}
@@ -0,0 +1,34 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/lifecycle"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_lifecycle_Worker(d *DihedralLifecycleComponent, resolution *di_import_2.Cleanups) (*target_pkg.Worker, error) {
+	target := &target_pkg.Worker{}
+	param0, err := d.provides_github_com_dimes_dihedral_internal_example_lifecycle_Config(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Worker
+		return zeroValue, err
+	}
+	target.Config = param0
+	param1, err := factory_github_com_dimes_dihedral_internal_example_lifecycle_Database(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Worker
+		return zeroValue, err
+	}
+	target.Database = param1
+	param2, err := d.provides_github_com_dimes_dihedral_internal_example_lifecycle_Session(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Worker
+		return zeroValue, err
+	}
+	target.Session = param2
+	if err := target.Init(); err != nil {
+		var zeroValue *target_pkg.Worker
+		return zeroValue, err
+	}
+	return target, nil
+}
@@ -0,0 +1,263 @@
+//go:generate dihedral -definition LifecycleDefinition
+
+// Package lifecycle contains a component whose injected structs are initialized
+// after they are constructed and cleaned up when the component is closed
+package lifecycle
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/dimes/dihedral/embeds"
+)
+
+var (
+	// ErrConnectFailed is returned when the connection of a transfer cannot be opened
+	ErrConnectFailed = errors.New("Connect failed")
+
+	// ErrReleaseFailed is returned when the lease of a transfer cannot be released
+	ErrReleaseFailed = errors.New("Release failed")
+)
+
+// Config configures the database
+type Config struct {
+	URL         string
+	FailClose   bool
+	FailWorker  bool
+	FailConnect bool
+}
+
+// Log records the lifecycle events of the component
+type Log struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+
+	lock    sync.Mutex
+	entries []string
+}
+
+// Record appends an entry to the log
+func (l *Log) Record(entry string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.entries = append(l.entries, entry)
+}
+
+// Entries returns the recorded entries
+func (l *Log) Entries() []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]string{}, l.entries...)
+}
+
+// Database is connected by its Init method
+type Database struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+	Config    *Config
+	Log       *Log
+
+	connected bool
+}
+
+// Init connects to the database
+func (d *Database) Init() error {
+	if d.Config.URL == "" {
+		return errors.New("No database URL")
+	}
+
+	d.connected = true
+	d.Log.Record("database initialized")
+	return nil
+}
+
+// Connected returns true if the database was initialized
+func (d *Database) Connected() bool {
+	return d.connected
+}
+
+// Close disconnects from the database
+func (d *Database) Close() error {
+	d.connected = false
+	d.Log.Record("database closed")
+	if d.Config.FailClose {
+		return errors.New("Database close failed")
+	}
+
+	return nil
+}
+
+// Listener accepts connections until it is closed
+type Listener struct {
+	Open bool
+}
+
+// Store is provided by a module and closed when the component is closed, because it
+// has a Close method
+type Store struct {
+	Log  *Log
+	Open bool
+}
+
+// Close closes the store
+func (s *Store) Close() error {
+	s.Open = false
+	s.Log.Record("store closed")
+	return nil
+}
+
+// Service is initialized after the database it depends on
+type Service struct {
+	inject   embeds.Inject
+	Database *Database
+	Listener *Listener
+	Log      *Log
+}
+
+// Init records that the service is initialized
+func (s *Service) Init() error {
+	s.Log.Record("service initialized")
+	return nil
+}
+
+// Session is opened for every worker
+type Session struct {
+	Open bool
+}
+
+// Worker fails to initialize if the configuration says so, after its session was opened
+type Worker struct {
+	inject   embeds.Inject
+	Config   *Config
+	Database *Database
+	Session  *Session
+}
+
+// Init fails if the configuration says so
+func (w *Worker) Init() error {
+	if w.Config.FailWorker {
+		return errors.New("Worker failed")
+	}
+
+	return nil
+}
+
+// Lease is acquired before the connection of a transfer is opened
+type Lease struct {
+	Held bool
+}
+
+// Token authorizes a transfer. It does not need to be cleaned up.
+type Token struct {
+	Value string
+}
+
+// Connection is opened for every transfer
+type Connection struct{}
+
+// Transfer holds a lease while it uses its connection. The lease is acquired first,
+// so it is released if the connection cannot be opened.
+type Transfer struct {
+	inject     embeds.Inject
+	Lease      *Lease
+	Token      *Token
+	Connection *Connection
+}
+
+// ConfigModule provides the configuration
+type ConfigModule struct {
+	provided    embeds.ProvidedModule
+	URL         string
+	FailClose   bool
+	FailWorker  bool
+	FailConnect bool
+}
+
+// ProvidesConfig provides the configuration
+func (c *ConfigModule) ProvidesConfig() *Config {
+	return &Config{URL: c.URL, FailClose: c.FailClose, FailWorker: c.FailWorker, FailConnect: c.FailConnect}
+}
+
+// ListenerModule provides the listener
+type ListenerModule struct {
+	singleton embeds.Singleton
+}
+
+// ProvidesListener opens the listener and returns the function that closes it
+func (l *ListenerModule) ProvidesListener(config *Config, log *Log) (*Listener, func() error, error) {
+	listener := &Listener{Open: true}
+	return listener, func() error {
+		listener.Open = false
+		log.Record("listener closed")
+		if config.FailClose {
+			return errors.New("Listener close failed")
+		}
+
+		return nil
+	}, nil
+}
+
+// ProvidesStore opens the store
+func (l *ListenerModule) ProvidesStore(log *Log) *Store {
+	return &Store{Log: log, Open: true}
+}
+
+// SessionModule provides a new session every time one is injected
+type SessionModule struct{}
+
+// ProvidesSession opens a session and returns the function that closes it
+func (s *SessionModule) ProvidesSession(log *Log) (*Session, func()) {
+	session := &Session{Open: true}
+	return session, func() {
+		session.Open = false
+		log.Record("session closed")
+	}
+}
+
+// ProvidesLease acquires a lease and returns the function that releases it
+func (s *SessionModule) ProvidesLease(config *Config, log *Log) (*Lease, func() error) {
+	lease := &Lease{Held: true}
+	return lease, func() error {
+		lease.Held = false
+		log.Record("lease released")
+		if config.FailClose {
+			return ErrReleaseFailed
+		}
+
+		return nil
+	}
+}
+
+// ProvidesToken issues a token. Its cleanup function is nil, because there is nothing
+// to clean up.
+func (s *SessionModule) ProvidesToken() (*Token, func()) {
+	return &Token{Value: "token"}, nil
+}
+
+// ProvidesConnection opens a connection, which fails if the configuration says so
+func (s *SessionModule) ProvidesConnection(config *Config) (*Connection, error) {
+	if config.FailConnect {
+		return nil, ErrConnectFailed
+	}
+
+	return &Connection{}, nil
+}
+
+// LifecycleDefinition defines the LifecycleComponent
+type LifecycleDefinition interface {
+	Modules() (*ConfigModule, *ListenerModule, *SessionModule)
+	Target() LifecycleComponent
+}
+
+// LifecycleComponent returns the service and the log. Closing the component
+// closes the listener and the database.
+type LifecycleComponent interface {
+	io.Closer
+	GetService() (*Service, error)
+	GetLog() *Log
+	GetWorker() (*Worker, error)
+	OpenWorker() (*Worker, func() error, error)
+	GetTransfer() (*Transfer, error)
+	GetStore() (*Store, error)
+}
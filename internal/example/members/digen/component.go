@@ -0,0 +1,74 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/members"
+)
+
+type DihedralMembersComponent struct {
+	cleanups                                                                   di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_members_GreetingModule          *di_import_1.GreetingModule
+	singleton_github_com_dimes_dihedral_internal_example_members_Database      *di_import_1.Database
+	singleton_github_com_dimes_dihedral_internal_example_members_Database_lock di_import_2.SingletonLock
+}
+
+func NewDihedralMembersComponent() *DihedralMembersComponent {
+	return &DihedralMembersComponent{
+		github_com_dimes_dihedral_internal_example_members_GreetingModule: &di_import_1.GreetingModule{},
+	}
+}
+func (d *DihedralMembersComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralMembersComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralMembersComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralMembersComponent) GetDatabase() (*di_import_1.Database, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Database, error) {
+		return factory_github_com_dimes_dihedral_internal_example_members_Database(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Database
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralMembersComponent) InjectAdmin(target *di_import_1.Admin) error {
+	_, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Admin, error) {
+		param0, err := d.provides_github_com_dimes_dihedral_internal_example_members_Greeting(resolution)
+		if err != nil {
+			return nil, err
+		}
+		param1, err := d.provides_github_com_dimes_dihedral_internal_example_members_Secret(resolution)
+		if err != nil {
+			return nil, err
+		}
+		target.Greeting = param0
+		target.Secret = param1
+		return target, nil
+	})
+	return err
+}
+func (d *DihedralMembersComponent) InjectHandler(target *di_import_1.Handler) {
+	_, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Handler, error) {
+		param0, err := d.provides_github_com_dimes_dihedral_internal_example_members_Greeting(resolution)
+		if err != nil {
+			return nil, err
+		}
+		param1, err := factory_github_com_dimes_dihedral_internal_example_members_Database(d, resolution)
+		if err != nil {
+			return nil, err
+		}
+		target.Greeting = param0
+		target.Database = param1
+		return target, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
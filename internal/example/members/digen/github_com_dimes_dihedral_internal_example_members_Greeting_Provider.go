@@ -0,0 +1,12 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/members"
+)
+
+func (d *DihedralMembersComponent) provides_github_com_dimes_dihedral_internal_example_members_Greeting(resolution *di_import_2.Cleanups) (target_pkg.Greeting, error) {
+	returnValue := d.github_com_dimes_dihedral_internal_example_members_GreetingModule.ProvidesGreeting()
+	return returnValue, nil
+}
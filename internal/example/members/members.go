@@ -0,0 +1,64 @@
+//go:generate dihedral -definition MembersDefinition
+
+// Package members contains a component that injects the fields of structs created
+// outside of the component
+package members
+
+import (
+	"errors"
+
+	"github.com/dimes/dihedral/embeds"
+)
+
+// Greeting is the greeting of the handlers
+type Greeting string
+
+// Secret is the secret of the admin handlers
+type Secret string
+
+// GreetingModule provides the greeting and the secret
+type GreetingModule struct{}
+
+// ProvidesGreeting provides the greeting
+func (g *GreetingModule) ProvidesGreeting() Greeting {
+	return "Hello"
+}
+
+// ProvidesSecret fails, since no secret is configured
+func (g *GreetingModule) ProvidesSecret() (Secret, error) {
+	return "", errors.New("No secret configured")
+}
+
+// Database is shared by all handlers
+type Database struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+}
+
+// Handler is created by a router, which passes it to the component to inject its fields
+type Handler struct {
+	inject   embeds.Inject
+	Greeting Greeting
+	Database *Database
+	Path     string `di:"-"`
+}
+
+// Admin cannot be injected, since there is no secret
+type Admin struct {
+	inject   embeds.Inject
+	Greeting Greeting
+	Secret   Secret
+}
+
+// MembersDefinition defines the target and the modules to include
+type MembersDefinition interface {
+	Modules() *GreetingModule
+	Target() MembersComponent
+}
+
+// MembersComponent is the component under test
+type MembersComponent interface {
+	InjectHandler(handler *Handler)
+	InjectAdmin(admin *Admin) error
+	GetDatabase() (*Database, error)
+}
@@ -0,0 +1,72 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_4 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/params"
+	di_import_3 "io"
+	di_import_2 "net/http"
+)
+
+type DihedralParamsComponent struct {
+	cleanups                                                                   di_import_4.Cleanups
+	github_com_dimes_dihedral_internal_example_params_UserModule               *di_import_1.UserModule
+	singleton_github_com_dimes_dihedral_internal_example_params_Templates      *di_import_1.Templates
+	singleton_github_com_dimes_dihedral_internal_example_params_Templates_lock di_import_4.SingletonLock
+}
+
+func NewDihedralParamsComponent() *DihedralParamsComponent {
+	return &DihedralParamsComponent{
+		github_com_dimes_dihedral_internal_example_params_UserModule: &di_import_1.UserModule{},
+	}
+}
+func (d *DihedralParamsComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralParamsComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralParamsComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralParamsComponent) GetHandler(param0 *di_import_2.Request) (*di_import_1.Handler, error) {
+	obj, err := di_import_4.Resolve(di_import_4.WithParam(context.Background(), "net/http.Request", param0), &d.cleanups, func(resolution *di_import_4.Cleanups) (*di_import_1.Handler, error) {
+		return factory_github_com_dimes_dihedral_internal_example_params_Handler(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Handler
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralParamsComponent) GetJob(ctx context.Context, param1 di_import_1.Priority) (*di_import_1.Job, error) {
+	obj, err := di_import_4.Resolve(di_import_4.WithParam(ctx, "github.com/dimes/dihedral/internal/example/params.Priority", param1), &d.cleanups, func(resolution *di_import_4.Cleanups) (*di_import_1.Job, error) {
+		return factory_github_com_dimes_dihedral_internal_example_params_Job(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Job
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralParamsComponent) GetReport(param0 di_import_3.Writer) (*di_import_1.Report, error) {
+	obj, err := di_import_4.Resolve(di_import_4.WithParam(context.Background(), "io.Writer", param0), &d.cleanups, func(resolution *di_import_4.Cleanups) (*di_import_1.Report, error) {
+		return factory_github_com_dimes_dihedral_internal_example_params_Report(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Report
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralParamsComponent) GetTemplates() (*di_import_1.Templates, error) {
+	obj, err := di_import_4.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_4.Cleanups) (*di_import_1.Templates, error) {
+		return factory_github_com_dimes_dihedral_internal_example_params_Templates(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Templates
+		return zeroValue, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,41 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/params"
+	di_import_3 "net/http"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_params_Handler(d *DihedralParamsComponent, resolution *di_import_2.Cleanups) (*target_pkg.Handler, error) {
+	target := &target_pkg.Handler{}
+	param0, err := di_import_2.Param[*di_import_3.Request](resolution, "net/http.Request")
+	if err != nil {
+		var zeroValue *target_pkg.Handler
+		return zeroValue, err
+	}
+	target.Request = param0
+	param1, err := d.provides_github_com_dimes_dihedral_internal_example_params_User(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Handler
+		return zeroValue, err
+	}
+	target.User = param1
+	param2, err := factory_github_com_dimes_dihedral_internal_example_params_Templates(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Handler
+		return zeroValue, err
+	}
+	target.Templates = param2
+	param3, err := di_import_2.Provider[*target_pkg.Session](func() (*target_pkg.Session, error) {
+		return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Session, error) {
+			return factory_github_com_dimes_dihedral_internal_example_params_Session(d, resolution)
+		})
+	}), error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Handler
+		return zeroValue, err
+	}
+	target.Sessions = param3
+	return target, nil
+}
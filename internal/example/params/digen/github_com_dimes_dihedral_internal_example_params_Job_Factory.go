@@ -0,0 +1,24 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/params"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_params_Job(d *DihedralParamsComponent, resolution *di_import_2.Cleanups) (*target_pkg.Job, error) {
+	target := &target_pkg.Job{}
+	param0, err := di_import_2.Param[target_pkg.Priority](resolution, "github.com/dimes/dihedral/internal/example/params.Priority")
+	if err != nil {
+		var zeroValue *target_pkg.Job
+		return zeroValue, err
+	}
+	target.Priority = param0
+	param1, err := factory_github_com_dimes_dihedral_internal_example_params_Templates(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Job
+		return zeroValue, err
+	}
+	target.Templates = param1
+	return target, nil
+}
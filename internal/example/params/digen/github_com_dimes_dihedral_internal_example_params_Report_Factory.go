@@ -0,0 +1,19 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/params"
+	di_import_3 "io"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_params_Report(d *DihedralParamsComponent, resolution *di_import_2.Cleanups) (*target_pkg.Report, error) {
+	target := &target_pkg.Report{}
+	param0, err := di_import_2.Param[di_import_3.Writer](resolution, "io.Writer")
+	if err != nil {
+		var zeroValue *target_pkg.Report
+		return zeroValue, err
+	}
+	target.Output = param0
+	return target, nil
+}
@@ -0,0 +1,19 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/params"
+	di_import_3 "net/http"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_params_Session(d *DihedralParamsComponent, resolution *di_import_2.Cleanups) (*target_pkg.Session, error) {
+	target := &target_pkg.Session{}
+	param0, err := di_import_2.Param[*di_import_3.Request](resolution, "net/http.Request")
+	if err != nil {
+		var zeroValue *target_pkg.Session
+		return zeroValue, err
+	}
+	target.Request = param0
+	return target, nil
+}
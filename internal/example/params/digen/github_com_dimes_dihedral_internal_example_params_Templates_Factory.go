@@ -0,0 +1,32 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/params"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_params_Templates(d *DihedralParamsComponent, resolution *di_import_2.Cleanups) (*target_pkg.Templates, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_params_Templates_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_params_Templates, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_params_Templates_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/params.Templates"); err != nil {
+		var zeroValue *target_pkg.Templates
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_params_Templates_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_params_Templates_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_params_Templates, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_params_Templates_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Templates, error) {
+		target := &target_pkg.Templates{}
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Templates
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_params_Templates = value
+	d.singleton_github_com_dimes_dihedral_internal_example_params_Templates_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,20 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/params"
+	di_import_3 "net/http"
+)
+
+func (d *DihedralParamsComponent) provides_github_com_dimes_dihedral_internal_example_params_User(resolution *di_import_2.Cleanups) (target_pkg.User, error) {
+	param0, err := di_import_2.Param[*di_import_3.Request](resolution, "net/http.Request")
+	if err != nil {
+		var zeroValue target_pkg.User
+		return zeroValue, err
+	}
+	returnValue := d.github_com_dimes_dihedral_internal_example_params_UserModule.ProvidesUser(
+		param0,
+	)
+	return returnValue, nil
+}
@@ -0,0 +1,76 @@
+//go:generate dihedral -definition ParamsDefinition
+
+// Package params contains a component whose targets take parameters that are
+// injected while the targets are created
+package params
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/dimes/dihedral/embeds"
+	"github.com/dimes/dihedral/inject"
+)
+
+// User is the user that made a request
+type User string
+
+// UserModule provides the user from the request
+type UserModule struct{}
+
+// ProvidesUser reads the user from the header of the request
+func (u *UserModule) ProvidesUser(request *http.Request) User {
+	return User(request.Header.Get("X-User"))
+}
+
+// Templates are shared by all requests
+type Templates struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+}
+
+// Session is created for the request of the handler whenever it is needed
+type Session struct {
+	inject  embeds.Inject
+	Request *http.Request
+}
+
+// Handler handles a single request
+type Handler struct {
+	inject    embeds.Inject
+	Request   *http.Request
+	User      User
+	Templates *Templates
+	Sessions  inject.Provider[*Session]
+}
+
+// Priority is the priority of a job
+type Priority int
+
+// Job runs with the priority it was created with
+type Job struct {
+	inject    embeds.Inject
+	Priority  Priority
+	Templates *Templates
+}
+
+// Report is written to the output it was created with, which can be nil
+type Report struct {
+	inject embeds.Inject
+	Output io.Writer
+}
+
+// ParamsDefinition defines the target and the modules to include
+type ParamsDefinition interface {
+	Modules() *UserModule
+	Target() ParamsComponent
+}
+
+// ParamsComponent is the component under test
+type ParamsComponent interface {
+	GetHandler(request *http.Request) (*Handler, error)
+	GetJob(ctx context.Context, priority Priority) (*Job, error)
+	GetTemplates() (*Templates, error)
+	GetReport(output io.Writer) (*Report, error)
+}
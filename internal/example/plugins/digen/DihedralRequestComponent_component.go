@@ -0,0 +1,33 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/plugins"
+)
+
+type DihedralRequestComponent struct {
+	parent   *DihedralPluginsComponent
+	cleanups di_import_2.Cleanups
+}
+
+func (d *DihedralRequestComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralRequestComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralRequestComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralRequestComponent) GetSession() (*di_import_1.Session, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Session, error) {
+		return factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_plugins_Session(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Session
+		return zeroValue, err
+	}
+	return obj, nil
+}
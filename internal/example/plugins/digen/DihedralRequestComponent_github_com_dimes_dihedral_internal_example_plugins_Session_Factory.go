@@ -0,0 +1,24 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/plugins"
+)
+
+func factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_plugins_Session(d *DihedralRequestComponent, resolution *di_import_2.Cleanups) (*target_pkg.Session, error) {
+	target := &target_pkg.Session{}
+	param0, err := d, error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Session
+		return zeroValue, err
+	}
+	target.Request = param0
+	param1, err := d.parent, error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Session
+		return zeroValue, err
+	}
+	target.Plugins = param1
+	return target, nil
+}
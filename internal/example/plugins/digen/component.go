@@ -0,0 +1,55 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/plugins"
+)
+
+type DihedralPluginsComponent struct {
+	cleanups                                                                 di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_plugins_PluginsModule         *di_import_1.PluginsModule
+	singleton_github_com_dimes_dihedral_internal_example_plugins_Loader      *di_import_1.Loader
+	singleton_github_com_dimes_dihedral_internal_example_plugins_Loader_lock di_import_2.SingletonLock
+}
+
+func NewDihedralPluginsComponent() *DihedralPluginsComponent {
+	return &DihedralPluginsComponent{
+		github_com_dimes_dihedral_internal_example_plugins_PluginsModule: &di_import_1.PluginsModule{},
+	}
+}
+func (d *DihedralPluginsComponent) NewRequestComponent() di_import_1.RequestComponent {
+	return &DihedralRequestComponent{
+		parent: d,
+	}
+}
+func (d *DihedralPluginsComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralPluginsComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralPluginsComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralPluginsComponent) GetGreeter() (*di_import_1.Greeter, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Greeter, error) {
+		return factory_github_com_dimes_dihedral_internal_example_plugins_Greeter(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Greeter
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralPluginsComponent) GetLoader() (*di_import_1.Loader, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Loader, error) {
+		return factory_github_com_dimes_dihedral_internal_example_plugins_Loader(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Loader
+		return zeroValue, err
+	}
+	return obj, nil
+}
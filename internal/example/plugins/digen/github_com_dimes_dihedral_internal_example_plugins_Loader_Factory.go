@@ -0,0 +1,44 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/plugins"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_plugins_Loader(d *DihedralPluginsComponent, resolution *di_import_2.Cleanups) (*target_pkg.Loader, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_plugins_Loader_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_plugins_Loader, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_plugins_Loader_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/plugins.Loader"); err != nil {
+		var zeroValue *target_pkg.Loader
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_plugins_Loader_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_plugins_Loader_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_plugins_Loader, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_plugins_Loader_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Loader, error) {
+		target := &target_pkg.Loader{}
+		param0, err := d, error(nil)
+		if err != nil {
+			var zeroValue *target_pkg.Loader
+			return zeroValue, err
+		}
+		target.Component = param0
+		param1, err := d.provides_github_com_dimes_dihedral_internal_example_plugins_Lookup(resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Loader
+			return zeroValue, err
+		}
+		target.Lookup = param1
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Loader
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_plugins_Loader = value
+	d.singleton_github_com_dimes_dihedral_internal_example_plugins_Loader_lock.MarkDone()
+	return value, nil
+}
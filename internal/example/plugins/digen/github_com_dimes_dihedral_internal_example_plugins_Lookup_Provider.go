@@ -0,0 +1,19 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/plugins"
+)
+
+func (d *DihedralPluginsComponent) provides_github_com_dimes_dihedral_internal_example_plugins_Lookup(resolution *di_import_2.Cleanups) (target_pkg.Lookup, error) {
+	param0, err := d, error(nil)
+	if err != nil {
+		var zeroValue target_pkg.Lookup
+		return zeroValue, err
+	}
+	returnValue := d.github_com_dimes_dihedral_internal_example_plugins_PluginsModule.ProvidesLookup(
+		param0,
+	)
+	return returnValue, nil
+}
@@ -0,0 +1,68 @@
+//go:generate dihedral -definition PluginsDefinition
+
+// Package plugins contains a component that injects itself, so that types can resolve
+// other types from the component after they are created
+package plugins
+
+import (
+	"github.com/dimes/dihedral/embeds"
+)
+
+// Greeter is resolved from the component when it is needed
+type Greeter struct {
+	inject embeds.Inject
+}
+
+// Greet returns a greeting
+func (g *Greeter) Greet() string {
+	return "Hello"
+}
+
+// Lookup resolves the greeter
+type Lookup func() (*Greeter, error)
+
+// PluginsModule provides the lookup from the component
+type PluginsModule struct{}
+
+// ProvidesLookup provides the method of the component that resolves the greeter
+func (p *PluginsModule) ProvidesLookup(component PluginsComponent) Lookup {
+	return component.GetGreeter
+}
+
+// Loader loads plugins from the component it is injected with
+type Loader struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+	Component PluginsComponent
+	Lookup    Lookup
+}
+
+// PluginsDefinition defines the target and the modules to include
+type PluginsDefinition interface {
+	Modules() (*PluginsModule, RequestDefinition)
+	Target() PluginsComponent
+}
+
+// PluginsComponent is the component under test
+type PluginsComponent interface {
+	GetLoader() (*Loader, error)
+	GetGreeter() (*Greeter, error)
+	NewRequestComponent() RequestComponent
+}
+
+// Session is injected with the subcomponent that created it and its parent
+type Session struct {
+	inject  embeds.Inject
+	Request RequestComponent
+	Plugins PluginsComponent
+}
+
+// RequestDefinition defines a subcomponent
+type RequestDefinition interface {
+	Target() RequestComponent
+}
+
+// RequestComponent creates sessions
+type RequestComponent interface {
+	GetSession() (*Session, error)
+}
@@ -0,0 +1,47 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/qualifiers"
+)
+
+type DihedralQualifiersComponent struct {
+	cleanups                                                             di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_qualifiers_DatabaseModule *di_import_1.DatabaseModule
+}
+
+func NewDihedralQualifiersComponent() *DihedralQualifiersComponent {
+	return &DihedralQualifiersComponent{
+		github_com_dimes_dihedral_internal_example_qualifiers_DatabaseModule: &di_import_1.DatabaseModule{},
+	}
+}
+func (d *DihedralQualifiersComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralQualifiersComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralQualifiersComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralQualifiersComponent) GetReplica() *di_import_1.Database {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Database, error) {
+		return d.provides_github_com_dimes_dihedral_internal_example_qualifiers_Database_name_replica(resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralQualifiersComponent) GetRepository() (*di_import_1.Repository, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Repository, error) {
+		return factory_github_com_dimes_dihedral_internal_example_qualifiers_Repository(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Repository
+		return zeroValue, err
+	}
+	return obj, nil
+}
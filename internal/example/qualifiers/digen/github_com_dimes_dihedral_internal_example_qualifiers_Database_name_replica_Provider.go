@@ -0,0 +1,12 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/qualifiers"
+)
+
+func (d *DihedralQualifiersComponent) provides_github_com_dimes_dihedral_internal_example_qualifiers_Database_name_replica(resolution *di_import_2.Cleanups) (*target_pkg.Database, error) {
+	returnValue := d.github_com_dimes_dihedral_internal_example_qualifiers_DatabaseModule.ProvidesReplica()
+	return returnValue, nil
+}
@@ -0,0 +1,12 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/qualifiers"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_qualifiers_MemoryCache(d *DihedralQualifiersComponent, resolution *di_import_2.Cleanups) (*target_pkg.MemoryCache, error) {
+	target := &target_pkg.MemoryCache{}
+	return target, nil
+}
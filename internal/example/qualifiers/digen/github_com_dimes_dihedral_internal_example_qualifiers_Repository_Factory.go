@@ -0,0 +1,40 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/qualifiers"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_qualifiers_Repository(d *DihedralQualifiersComponent, resolution *di_import_2.Cleanups) (*target_pkg.Repository, error) {
+	target := &target_pkg.Repository{}
+	param0, err := d.provides_github_com_dimes_dihedral_internal_example_qualifiers_Database_name_primary(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Repository
+		return zeroValue, err
+	}
+	target.Primary = param0
+	param1, err := di_import_2.NewLazy[*target_pkg.Database](func() (*target_pkg.Database, error) {
+		return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Database, error) {
+			return d.provides_github_com_dimes_dihedral_internal_example_qualifiers_Database_name_replica(resolution)
+		})
+	}), error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Repository
+		return zeroValue, err
+	}
+	target.Replica = param1
+	param2, err := factory_github_com_dimes_dihedral_internal_example_qualifiers_MemoryCache(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Repository
+		return zeroValue, err
+	}
+	target.Sessions = param2
+	param3, err := di_import_2.Optional[*target_pkg.Database]{}, error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Repository
+		return zeroValue, err
+	}
+	target.Archive = param3
+	return target, nil
+}
@@ -0,0 +1,76 @@
+//go:generate dihedral -definition QualifiersDefinition
+
+// Package qualifiers contains a component that injects several values of the
+// same type, which are distinguished by qualifiers
+package qualifiers
+
+import (
+	"github.com/dimes/dihedral/embeds"
+	"github.com/dimes/dihedral/inject"
+)
+
+// Database is a connection to a database
+type Database struct {
+	Name string
+}
+
+// Cache caches values
+type Cache interface {
+	Name() string
+}
+
+// MemoryCache is a cache in memory
+type MemoryCache struct {
+	inject embeds.Inject
+}
+
+// Name returns the name of the cache
+func (m *MemoryCache) Name() string {
+	return "memory"
+}
+
+// Repository reads from the replica and writes to the primary database
+type Repository struct {
+	inject   embeds.Inject
+	Primary  *Database                  `di:"name=primary"`
+	Replica  inject.Lazy[*Database]     `di:"name=replica"`
+	Sessions Cache                      `di:"name=sessions"`
+	Archive  inject.Optional[*Database] `di:"name=archive"`
+}
+
+// DatabaseModule provides the databases
+type DatabaseModule struct{}
+
+// ProvidesPrimary provides the primary database
+//
+//di:name primary
+func (d *DatabaseModule) ProvidesPrimary() *Database {
+	return &Database{Name: "primary"}
+}
+
+// ProvidesReplica provides the replica database
+//
+//di:name replica
+func (d *DatabaseModule) ProvidesReplica() *Database {
+	return &Database{Name: "replica"}
+}
+
+// CacheModule binds the caches
+type CacheModule interface {
+	//di:name sessions
+	BindsSessionCache(impl *MemoryCache) Cache
+}
+
+// QualifiersDefinition defines the QualifiersComponent
+type QualifiersDefinition interface {
+	Modules() (*DatabaseModule, CacheModule)
+	Target() QualifiersComponent
+}
+
+// QualifiersComponent returns the repository and the replica
+type QualifiersComponent interface {
+	GetRepository() (*Repository, error)
+
+	//di:name replica
+	GetReplica() *Database
+}
@@ -0,0 +1,32 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	target_pkg "bytes"
+	di_import_2 "github.com/dimes/dihedral/inject"
+)
+
+func (d *DihedralUnnamedComponent) provides_bytes_Buffer(resolution *di_import_2.Cleanups) (*target_pkg.Buffer, error) {
+	if d.singleton_bytes_Buffer_lock.Done() {
+		return d.singleton_bytes_Buffer, nil
+	}
+	if err := d.singleton_bytes_Buffer_lock.Lock(resolution, "bytes.Buffer"); err != nil {
+		var zeroValue *target_pkg.Buffer
+		return zeroValue, err
+	}
+	defer d.singleton_bytes_Buffer_lock.Unlock()
+	if d.singleton_bytes_Buffer_lock.Done() {
+		return d.singleton_bytes_Buffer, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_bytes_Buffer_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Buffer, error) {
+		returnValue := d.github_com_dimes_dihedral_internal_example_unnamed_ConfigModule.ProvidesBuffer()
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Buffer
+		return zeroValue, err
+	}
+	d.singleton_bytes_Buffer = value
+	d.singleton_bytes_Buffer_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,32 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/unnamed"
+)
+
+func (d *DihedralUnnamedComponent) provides_chan_github_com_dimes_dihedral_internal_example_unnamed_Event(resolution *di_import_2.Cleanups) (chan di_import_1.Event, error) {
+	if d.singleton_chan_github_com_dimes_dihedral_internal_example_unnamed_Event_lock.Done() {
+		return d.singleton_chan_github_com_dimes_dihedral_internal_example_unnamed_Event, nil
+	}
+	if err := d.singleton_chan_github_com_dimes_dihedral_internal_example_unnamed_Event_lock.Lock(resolution, "chan github.com/dimes/dihedral/internal/example/unnamed.Event"); err != nil {
+		var zeroValue chan di_import_1.Event
+		return zeroValue, err
+	}
+	defer d.singleton_chan_github_com_dimes_dihedral_internal_example_unnamed_Event_lock.Unlock()
+	if d.singleton_chan_github_com_dimes_dihedral_internal_example_unnamed_Event_lock.Done() {
+		return d.singleton_chan_github_com_dimes_dihedral_internal_example_unnamed_Event, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_chan_github_com_dimes_dihedral_internal_example_unnamed_Event_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (chan di_import_1.Event, error) {
+		returnValue := d.github_com_dimes_dihedral_internal_example_unnamed_ConfigModule.ProvidesEvents()
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue chan di_import_1.Event
+		return zeroValue, err
+	}
+	d.singleton_chan_github_com_dimes_dihedral_internal_example_unnamed_Event = value
+	d.singleton_chan_github_com_dimes_dihedral_internal_example_unnamed_Event_lock.MarkDone()
+	return value, nil
+}
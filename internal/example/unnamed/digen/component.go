@@ -0,0 +1,73 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "bytes"
+	"context"
+	di_import_4 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/unnamed"
+	di_import_3 "time"
+)
+
+type DihedralUnnamedComponent struct {
+	cleanups                                                                     di_import_4.Cleanups
+	github_com_dimes_dihedral_internal_example_unnamed_ConfigModule              *di_import_1.ConfigModule
+	singleton_slice_string                                                       []string
+	singleton_slice_string_lock                                                  di_import_4.SingletonLock
+	singleton_chan_github_com_dimes_dihedral_internal_example_unnamed_Event      chan di_import_1.Event
+	singleton_chan_github_com_dimes_dihedral_internal_example_unnamed_Event_lock di_import_4.SingletonLock
+	singleton_slice_string_name_backups                                          []string
+	singleton_slice_string_name_backups_lock                                     di_import_4.SingletonLock
+	singleton_bytes_Buffer                                                       *di_import_2.Buffer
+	singleton_bytes_Buffer_lock                                                  di_import_4.SingletonLock
+	singleton_int                                                                int
+	singleton_int_lock                                                           di_import_4.SingletonLock
+	singleton_map_string_to_int                                                  map[string]int
+	singleton_map_string_to_int_lock                                             di_import_4.SingletonLock
+	singleton_ptr_int                                                            *int
+	singleton_ptr_int_lock                                                       di_import_4.SingletonLock
+	singleton_func___time_Time                                                   func() di_import_3.Time
+	singleton_func___time_Time_lock                                              di_import_4.SingletonLock
+}
+
+func NewDihedralUnnamedComponent() *DihedralUnnamedComponent {
+	return &DihedralUnnamedComponent{
+		github_com_dimes_dihedral_internal_example_unnamed_ConfigModule: &di_import_1.ConfigModule{},
+	}
+}
+func (d *DihedralUnnamedComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralUnnamedComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralUnnamedComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralUnnamedComponent) GetConfig() *di_import_1.Config {
+	obj, err := di_import_4.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_4.Cleanups) (*di_import_1.Config, error) {
+		return factory_github_com_dimes_dihedral_internal_example_unnamed_Config(d, resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralUnnamedComponent) GetEvents() chan di_import_1.Event {
+	obj, err := di_import_4.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_4.Cleanups) (chan di_import_1.Event, error) {
+		return d.provides_chan_github_com_dimes_dihedral_internal_example_unnamed_Event(resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralUnnamedComponent) GetHosts() []string {
+	obj, err := di_import_4.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_4.Cleanups) ([]string, error) {
+		return d.provides_slice_string(resolution)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
@@ -0,0 +1,32 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "time"
+)
+
+func (d *DihedralUnnamedComponent) provides_func___time_Time(resolution *di_import_2.Cleanups) (func() di_import_1.Time, error) {
+	if d.singleton_func___time_Time_lock.Done() {
+		return d.singleton_func___time_Time, nil
+	}
+	if err := d.singleton_func___time_Time_lock.Lock(resolution, "func() time.Time"); err != nil {
+		var zeroValue func() di_import_1.Time
+		return zeroValue, err
+	}
+	defer d.singleton_func___time_Time_lock.Unlock()
+	if d.singleton_func___time_Time_lock.Done() {
+		return d.singleton_func___time_Time, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_func___time_Time_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (func() di_import_1.Time, error) {
+		returnValue := d.github_com_dimes_dihedral_internal_example_unnamed_ConfigModule.ProvidesNow()
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue func() di_import_1.Time
+		return zeroValue, err
+	}
+	d.singleton_func___time_Time = value
+	d.singleton_func___time_Time_lock.MarkDone()
+	return value, nil
+}
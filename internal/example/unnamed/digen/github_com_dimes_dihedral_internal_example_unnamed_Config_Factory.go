@@ -0,0 +1,64 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/unnamed"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_unnamed_Config(d *DihedralUnnamedComponent, resolution *di_import_2.Cleanups) (*target_pkg.Config, error) {
+	target := &target_pkg.Config{}
+	param0, err := d.provides_slice_string(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Config
+		return zeroValue, err
+	}
+	target.Hosts = param0
+	param1, err := d.provides_map_string_to_int(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Config
+		return zeroValue, err
+	}
+	target.Limits = param1
+	param2, err := d.provides_func___time_Time(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Config
+		return zeroValue, err
+	}
+	target.Now = param2
+	param3, err := d.provides_chan_github_com_dimes_dihedral_internal_example_unnamed_Event(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Config
+		return zeroValue, err
+	}
+	target.Events = param3
+	param4, err := d.provides_ptr_int(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Config
+		return zeroValue, err
+	}
+	target.Retries = param4
+	param5, err := d.provides_int(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Config
+		return zeroValue, err
+	}
+	target.Port = param5
+	param6, err := d.provides_bytes_Buffer(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Config
+		return zeroValue, err
+	}
+	target.Buffer = param6
+	param7, err := di_import_2.NewLazy[[]string](func() ([]string, error) {
+		return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) ([]string, error) {
+			return d.provides_slice_string_name_backups(resolution)
+		})
+	}), error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Config
+		return zeroValue, err
+	}
+	target.Backups = param7
+	return target, nil
+}
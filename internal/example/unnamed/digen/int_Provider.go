@@ -0,0 +1,38 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_1 "github.com/dimes/dihedral/inject"
+)
+
+func (d *DihedralUnnamedComponent) provides_int(resolution *di_import_1.Cleanups) (int, error) {
+	if d.singleton_int_lock.Done() {
+		return d.singleton_int, nil
+	}
+	if err := d.singleton_int_lock.Lock(resolution, "int"); err != nil {
+		var zeroValue int
+		return zeroValue, err
+	}
+	defer d.singleton_int_lock.Unlock()
+	if d.singleton_int_lock.Done() {
+		return d.singleton_int, nil
+	}
+	value, err := di_import_1.Resolve(d.singleton_int_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_1.Cleanups) (int, error) {
+		param0, err := d.provides_map_string_to_int(resolution)
+		if err != nil {
+			var zeroValue int
+			return zeroValue, err
+		}
+		returnValue := d.github_com_dimes_dihedral_internal_example_unnamed_ConfigModule.ProvidesPort(
+			param0,
+		)
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue int
+		return zeroValue, err
+	}
+	d.singleton_int = value
+	d.singleton_int_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,31 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_1 "github.com/dimes/dihedral/inject"
+)
+
+func (d *DihedralUnnamedComponent) provides_map_string_to_int(resolution *di_import_1.Cleanups) (map[string]int, error) {
+	if d.singleton_map_string_to_int_lock.Done() {
+		return d.singleton_map_string_to_int, nil
+	}
+	if err := d.singleton_map_string_to_int_lock.Lock(resolution, "map[string]int"); err != nil {
+		var zeroValue map[string]int
+		return zeroValue, err
+	}
+	defer d.singleton_map_string_to_int_lock.Unlock()
+	if d.singleton_map_string_to_int_lock.Done() {
+		return d.singleton_map_string_to_int, nil
+	}
+	value, err := di_import_1.Resolve(d.singleton_map_string_to_int_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_1.Cleanups) (map[string]int, error) {
+		returnValue := d.github_com_dimes_dihedral_internal_example_unnamed_ConfigModule.ProvidesLimits()
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue map[string]int
+		return zeroValue, err
+	}
+	d.singleton_map_string_to_int = value
+	d.singleton_map_string_to_int_lock.MarkDone()
+	return value, nil
+}
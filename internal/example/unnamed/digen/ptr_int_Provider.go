@@ -0,0 +1,31 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_1 "github.com/dimes/dihedral/inject"
+)
+
+func (d *DihedralUnnamedComponent) provides_ptr_int(resolution *di_import_1.Cleanups) (*int, error) {
+	if d.singleton_ptr_int_lock.Done() {
+		return d.singleton_ptr_int, nil
+	}
+	if err := d.singleton_ptr_int_lock.Lock(resolution, "*int"); err != nil {
+		var zeroValue *int
+		return zeroValue, err
+	}
+	defer d.singleton_ptr_int_lock.Unlock()
+	if d.singleton_ptr_int_lock.Done() {
+		return d.singleton_ptr_int, nil
+	}
+	value, err := di_import_1.Resolve(d.singleton_ptr_int_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_1.Cleanups) (*int, error) {
+		returnValue := d.github_com_dimes_dihedral_internal_example_unnamed_ConfigModule.ProvidesRetries()
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue *int
+		return zeroValue, err
+	}
+	d.singleton_ptr_int = value
+	d.singleton_ptr_int_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,31 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_1 "github.com/dimes/dihedral/inject"
+)
+
+func (d *DihedralUnnamedComponent) provides_slice_string(resolution *di_import_1.Cleanups) ([]string, error) {
+	if d.singleton_slice_string_lock.Done() {
+		return d.singleton_slice_string, nil
+	}
+	if err := d.singleton_slice_string_lock.Lock(resolution, "[]string"); err != nil {
+		var zeroValue []string
+		return zeroValue, err
+	}
+	defer d.singleton_slice_string_lock.Unlock()
+	if d.singleton_slice_string_lock.Done() {
+		return d.singleton_slice_string, nil
+	}
+	value, err := di_import_1.Resolve(d.singleton_slice_string_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_1.Cleanups) ([]string, error) {
+		returnValue := d.github_com_dimes_dihedral_internal_example_unnamed_ConfigModule.ProvidesHosts()
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue []string
+		return zeroValue, err
+	}
+	d.singleton_slice_string = value
+	d.singleton_slice_string_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,94 @@
+//go:generate dihedral -definition UnnamedDefinition
+
+// Package unnamed contains a component that injects types without a name of
+// their own, like slices, maps, functions, channels and builtins
+package unnamed
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/dimes/dihedral/embeds"
+	"github.com/dimes/dihedral/inject"
+)
+
+// Event is sent on the events channel
+type Event struct {
+	Name string
+}
+
+// Config is configured by values that are all provided by the ConfigModule
+type Config struct {
+	inject  embeds.Inject
+	Hosts   []string
+	Limits  map[string]int
+	Now     func() time.Time
+	Events  chan Event
+	Retries *int
+	Port    int
+	Buffer  *bytes.Buffer
+	Backups inject.Lazy[[]string] `di:"name=backups"`
+}
+
+// ConfigModule provides the configuration values
+type ConfigModule struct {
+	singleton embeds.Singleton
+}
+
+// ProvidesHosts provides the hosts
+func (c *ConfigModule) ProvidesHosts() []string {
+	return []string{"first", "second"}
+}
+
+// ProvidesBackups provides the backup hosts
+//
+//di:name backups
+func (c *ConfigModule) ProvidesBackups() []string {
+	return []string{"backup"}
+}
+
+// ProvidesLimits provides the limits
+func (c *ConfigModule) ProvidesLimits() map[string]int {
+	return map[string]int{"requests": 100}
+}
+
+// ProvidesNow provides the clock
+func (c *ConfigModule) ProvidesNow() func() time.Time {
+	return func() time.Time {
+		return time.Unix(0, 0)
+	}
+}
+
+// ProvidesEvents provides the events channel
+func (c *ConfigModule) ProvidesEvents() chan Event {
+	return make(chan Event, 1)
+}
+
+// ProvidesRetries provides the number of retries
+func (c *ConfigModule) ProvidesRetries() *int {
+	retries := 3
+	return &retries
+}
+
+// ProvidesPort provides the port
+func (c *ConfigModule) ProvidesPort(limits map[string]int) int {
+	return 8000 + limits["requests"]
+}
+
+// ProvidesBuffer provides the buffer
+func (c *ConfigModule) ProvidesBuffer() *bytes.Buffer {
+	return &bytes.Buffer{}
+}
+
+// UnnamedDefinition defines the UnnamedComponent
+type UnnamedDefinition interface {
+	Modules() *ConfigModule
+	Target() UnnamedComponent
+}
+
+// UnnamedComponent returns the configuration
+type UnnamedComponent interface {
+	GetConfig() *Config
+	GetHosts() []string
+	GetEvents() chan Event
+}
@@ -0,0 +1,44 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+type DihedralRequestComponent struct {
+	parent                                                            *DihedralWrappersComponent
+	cleanups                                                          di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_wrappers_RequestModule *di_import_1.RequestModule
+}
+
+func (d *DihedralRequestComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralRequestComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralRequestComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralRequestComponent) GetPage() (*di_import_1.Page, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Page, error) {
+		return factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_wrappers_Page(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Page
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralRequestComponent) GetSession() (*di_import_1.Session, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Session, error) {
+		return factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_wrappers_Session(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Session
+		return zeroValue, err
+	}
+	return obj, nil
+}
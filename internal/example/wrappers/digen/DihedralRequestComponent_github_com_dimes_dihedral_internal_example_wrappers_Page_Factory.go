@@ -0,0 +1,22 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+func factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_wrappers_Page(d *DihedralRequestComponent, resolution *di_import_2.Cleanups) (*target_pkg.Page, error) {
+	target := &target_pkg.Page{}
+	param0, err := di_import_2.NewLazy[*target_pkg.Session](func() (*target_pkg.Session, error) {
+		return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Session, error) {
+			return factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_wrappers_Session(d, resolution)
+		})
+	}), error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Page
+		return zeroValue, err
+	}
+	target.Session = param0
+	return target, nil
+}
@@ -0,0 +1,24 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+func factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_wrappers_Session(d *DihedralRequestComponent, resolution *di_import_2.Cleanups) (*target_pkg.Session, error) {
+	target := &target_pkg.Session{}
+	param0, err := factory_DihedralRequestComponent_github_com_dimes_dihedral_internal_example_wrappers_Page(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Session
+		return zeroValue, err
+	}
+	target.Page = param0
+	param1, err := d.provides_github_com_dimes_dihedral_internal_example_wrappers_User(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Session
+		return zeroValue, err
+	}
+	target.User = param1
+	return target, nil
+}
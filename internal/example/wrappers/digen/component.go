@@ -0,0 +1,107 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	"context"
+	di_import_2 "github.com/dimes/dihedral/inject"
+	di_import_1 "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+type DihedralWrappersComponent struct {
+	cleanups                                                                    di_import_2.Cleanups
+	github_com_dimes_dihedral_internal_example_wrappers_ReportModule            *di_import_1.ReportModule
+	singleton_github_com_dimes_dihedral_internal_example_wrappers_Summary       *di_import_1.Summary
+	singleton_github_com_dimes_dihedral_internal_example_wrappers_Summary_lock  di_import_2.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_wrappers_Registry      *di_import_1.Registry
+	singleton_github_com_dimes_dihedral_internal_example_wrappers_Registry_lock di_import_2.SingletonLock
+	singleton_github_com_dimes_dihedral_internal_example_wrappers_Monitor       *di_import_1.Monitor
+	singleton_github_com_dimes_dihedral_internal_example_wrappers_Monitor_lock  di_import_2.SingletonLock
+}
+
+func NewDihedralWrappersComponent(
+	github_com_dimes_dihedral_internal_example_wrappers_ReportModule *di_import_1.ReportModule,
+) *DihedralWrappersComponent {
+	return &DihedralWrappersComponent{
+		github_com_dimes_dihedral_internal_example_wrappers_ReportModule: github_com_dimes_dihedral_internal_example_wrappers_ReportModule,
+	}
+}
+func (d *DihedralWrappersComponent) NewRequestComponent(
+	github_com_dimes_dihedral_internal_example_wrappers_RequestModule *di_import_1.RequestModule,
+) di_import_1.RequestComponent {
+	return &DihedralRequestComponent{
+		parent: d,
+		github_com_dimes_dihedral_internal_example_wrappers_RequestModule: github_com_dimes_dihedral_internal_example_wrappers_RequestModule,
+	}
+}
+func (d *DihedralWrappersComponent) Close() error {
+	return d.cleanups.Close()
+}
+func (d *DihedralWrappersComponent) Start(ctx context.Context) error {
+	return d.cleanups.Start(ctx)
+}
+func (d *DihedralWrappersComponent) Stop(ctx context.Context) error {
+	return d.cleanups.Stop(ctx)
+}
+func (d *DihedralWrappersComponent) GetDashboard() (*di_import_1.Dashboard, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Dashboard, error) {
+		return factory_github_com_dimes_dihedral_internal_example_wrappers_Dashboard(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Dashboard
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralWrappersComponent) GetMonitor() (*di_import_1.Monitor, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Monitor, error) {
+		return factory_github_com_dimes_dihedral_internal_example_wrappers_Monitor(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Monitor
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralWrappersComponent) GetParent() (*di_import_1.Parent, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Parent, error) {
+		return factory_github_com_dimes_dihedral_internal_example_wrappers_Parent(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Parent
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralWrappersComponent) GetRegistry() (*di_import_1.Registry, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Registry, error) {
+		return factory_github_com_dimes_dihedral_internal_example_wrappers_Registry(d, resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Registry
+		return zeroValue, err
+	}
+	return obj, nil
+}
+func (d *DihedralWrappersComponent) GetReportProvider() di_import_2.Provider[*di_import_1.Report] {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (di_import_2.Provider[*di_import_1.Report], error) {
+		return di_import_2.Provider[*di_import_1.Report](func() (*di_import_1.Report, error) {
+			return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Report, error) {
+				return d.provides_github_com_dimes_dihedral_internal_example_wrappers_Report(resolution)
+			})
+		}), error(nil)
+	})
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+func (d *DihedralWrappersComponent) GetSummary() (*di_import_1.Summary, error) {
+	obj, err := di_import_2.Resolve(context.Background(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*di_import_1.Summary, error) {
+		return d.provides_github_com_dimes_dihedral_internal_example_wrappers_Summary(resolution)
+	})
+	if err != nil {
+		var zeroValue *di_import_1.Summary
+		return zeroValue, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,22 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_wrappers_Child(d *DihedralWrappersComponent, resolution *di_import_2.Cleanups) (*target_pkg.Child, error) {
+	target := &target_pkg.Child{}
+	param0, err := di_import_2.NewLazy[*target_pkg.Parent](func() (*target_pkg.Parent, error) {
+		return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Parent, error) {
+			return factory_github_com_dimes_dihedral_internal_example_wrappers_Parent(d, resolution)
+		})
+	}), error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Child
+		return zeroValue, err
+	}
+	target.Parent = param0
+	return target, nil
+}
@@ -0,0 +1,66 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_wrappers_Dashboard(d *DihedralWrappersComponent, resolution *di_import_2.Cleanups) (*target_pkg.Dashboard, error) {
+	target := &target_pkg.Dashboard{}
+	param0, err := di_import_2.NewLazy[*target_pkg.Report](func() (*target_pkg.Report, error) {
+		return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Report, error) {
+			return d.provides_github_com_dimes_dihedral_internal_example_wrappers_Report(resolution)
+		})
+	}), error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Dashboard
+		return zeroValue, err
+	}
+	target.Report = param0
+	param1, err := di_import_2.Provider[*target_pkg.Report](func() (*target_pkg.Report, error) {
+		return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Report, error) {
+			return d.provides_github_com_dimes_dihedral_internal_example_wrappers_Report(resolution)
+		})
+	}), error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Dashboard
+		return zeroValue, err
+	}
+	target.NewReport = param1
+	param2, err := di_import_2.Provider[target_pkg.Store](func() (target_pkg.Store, error) {
+		return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (target_pkg.Store, error) {
+			return factory_github_com_dimes_dihedral_internal_example_wrappers_MemoryStore(d, resolution)
+		})
+	}), error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Dashboard
+		return zeroValue, err
+	}
+	target.Store = param2
+	param3, err := di_import_2.Optional[target_pkg.Theme]{}, error(nil)
+	if err != nil {
+		var zeroValue *target_pkg.Dashboard
+		return zeroValue, err
+	}
+	target.Theme = param3
+	param4, err := func() (di_import_2.Optional[target_pkg.Store], error) {
+		obj, err := factory_github_com_dimes_dihedral_internal_example_wrappers_MemoryStore(d, resolution)
+		if err != nil {
+			return di_import_2.Optional[target_pkg.Store]{}, err
+		}
+		return di_import_2.NewOptional[target_pkg.Store](obj), nil
+	}()
+	if err != nil {
+		var zeroValue *target_pkg.Dashboard
+		return zeroValue, err
+	}
+	target.OptionalStore = param4
+	param5, err := d.provides_github_com_dimes_dihedral_internal_example_wrappers_Title(resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Dashboard
+		return zeroValue, err
+	}
+	target.Title = param5
+	return target, nil
+}
@@ -0,0 +1,46 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_wrappers_Monitor(d *DihedralWrappersComponent, resolution *di_import_2.Cleanups) (*target_pkg.Monitor, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Monitor_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Monitor, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Monitor_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/wrappers.Monitor"); err != nil {
+		var zeroValue *target_pkg.Monitor
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Monitor_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Monitor_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Monitor, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Monitor_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Monitor, error) {
+		target := &target_pkg.Monitor{}
+		param0, err := di_import_2.Provider[*target_pkg.Monitor](func() (*target_pkg.Monitor, error) {
+			return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Monitor, error) {
+				return factory_github_com_dimes_dihedral_internal_example_wrappers_Monitor(d, resolution)
+			})
+		}), error(nil)
+		if err != nil {
+			var zeroValue *target_pkg.Monitor
+			return zeroValue, err
+		}
+		target.Self = param0
+		if err := target.Init(); err != nil {
+			var zeroValue *target_pkg.Monitor
+			return zeroValue, err
+		}
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Monitor
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Monitor = value
+	d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Monitor_lock.MarkDone()
+	return value, nil
+}
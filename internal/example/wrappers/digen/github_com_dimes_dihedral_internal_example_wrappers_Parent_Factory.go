@@ -0,0 +1,18 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_wrappers_Parent(d *DihedralWrappersComponent, resolution *di_import_2.Cleanups) (*target_pkg.Parent, error) {
+	target := &target_pkg.Parent{}
+	param0, err := factory_github_com_dimes_dihedral_internal_example_wrappers_Child(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Parent
+		return zeroValue, err
+	}
+	target.Child = param0
+	return target, nil
+}
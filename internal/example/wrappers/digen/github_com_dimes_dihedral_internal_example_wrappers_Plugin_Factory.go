@@ -0,0 +1,18 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_wrappers_Plugin(d *DihedralWrappersComponent, resolution *di_import_2.Cleanups) (*target_pkg.Plugin, error) {
+	target := &target_pkg.Plugin{}
+	param0, err := factory_github_com_dimes_dihedral_internal_example_wrappers_Registry(d, resolution)
+	if err != nil {
+		var zeroValue *target_pkg.Plugin
+		return zeroValue, err
+	}
+	target.Registry = param0
+	return target, nil
+}
@@ -0,0 +1,46 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+func factory_github_com_dimes_dihedral_internal_example_wrappers_Registry(d *DihedralWrappersComponent, resolution *di_import_2.Cleanups) (*target_pkg.Registry, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Registry_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Registry, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Registry_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/wrappers.Registry"); err != nil {
+		var zeroValue *target_pkg.Registry
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Registry_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Registry_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Registry, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Registry_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Registry, error) {
+		target := &target_pkg.Registry{}
+		param0, err := di_import_2.Provider[*target_pkg.Plugin](func() (*target_pkg.Plugin, error) {
+			return di_import_2.Resolve(resolution.Context(), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Plugin, error) {
+				return factory_github_com_dimes_dihedral_internal_example_wrappers_Plugin(d, resolution)
+			})
+		}), error(nil)
+		if err != nil {
+			var zeroValue *target_pkg.Registry
+			return zeroValue, err
+		}
+		target.Plugins = param0
+		if err := target.Init(); err != nil {
+			var zeroValue *target_pkg.Registry
+			return zeroValue, err
+		}
+		return target, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Registry
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Registry = value
+	d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Registry_lock.MarkDone()
+	return value, nil
+}
@@ -0,0 +1,39 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+func (d *DihedralWrappersComponent) provides_github_com_dimes_dihedral_internal_example_wrappers_Summary(resolution *di_import_2.Cleanups) (*target_pkg.Summary, error) {
+	if d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Summary_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Summary, nil
+	}
+	if err := d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Summary_lock.Lock(resolution, "github.com/dimes/dihedral/internal/example/wrappers.Summary"); err != nil {
+		var zeroValue *target_pkg.Summary
+		return zeroValue, err
+	}
+	defer d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Summary_lock.Unlock()
+	if d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Summary_lock.Done() {
+		return d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Summary, nil
+	}
+	value, err := di_import_2.Resolve(d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Summary_lock.Creating(resolution), &d.cleanups, func(resolution *di_import_2.Cleanups) (*target_pkg.Summary, error) {
+		param0, err := d.provides_github_com_dimes_dihedral_internal_example_wrappers_Report(resolution)
+		if err != nil {
+			var zeroValue *target_pkg.Summary
+			return zeroValue, err
+		}
+		returnValue := d.github_com_dimes_dihedral_internal_example_wrappers_ReportModule.ProvidesSummary(
+			param0,
+		)
+		return returnValue, nil
+	})
+	if err != nil {
+		var zeroValue *target_pkg.Summary
+		return zeroValue, err
+	}
+	d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Summary = value
+	d.singleton_github_com_dimes_dihedral_internal_example_wrappers_Summary_lock.MarkDone()
+	return value, nil
+}
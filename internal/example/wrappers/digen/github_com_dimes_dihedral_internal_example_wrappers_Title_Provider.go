@@ -0,0 +1,31 @@
+// Code generated by go generate; DO NOT EDIT.
+package digen
+
+import (
+	di_import_2 "github.com/dimes/dihedral/inject"
+	target_pkg "github.com/dimes/dihedral/internal/example/wrappers"
+)
+
+func (d *DihedralWrappersComponent) provides_github_com_dimes_dihedral_internal_example_wrappers_Title(resolution *di_import_2.Cleanups) (target_pkg.Title, error) {
+	param0, err := di_import_2.Optional[target_pkg.Theme]{}, error(nil)
+	if err != nil {
+		var zeroValue target_pkg.Title
+		return zeroValue, err
+	}
+	param1, err := func() (di_import_2.Optional[target_pkg.Store], error) {
+		obj, err := factory_github_com_dimes_dihedral_internal_example_wrappers_MemoryStore(d, resolution)
+		if err != nil {
+			return di_import_2.Optional[target_pkg.Store]{}, err
+		}
+		return di_import_2.NewOptional[target_pkg.Store](obj), nil
+	}()
+	if err != nil {
+		var zeroValue target_pkg.Title
+		return zeroValue, err
+	}
+	returnValue := d.github_com_dimes_dihedral_internal_example_wrappers_ReportModule.ProvidesTitle(
+		param0,
+		param1,
+	)
+	return returnValue, nil
+}
@@ -0,0 +1,203 @@
+//go:generate dihedral -definition WrappersDefinition
+
+// Package wrappers contains a component that injects lazily created values
+// and providers of values
+package wrappers
+
+import (
+	"sync/atomic"
+
+	"github.com/dimes/dihedral/embeds"
+	"github.com/dimes/dihedral/inject"
+)
+
+// Report is expensive to create. The module counts how many reports were created.
+type Report struct {
+	Number int32
+}
+
+// Summary is created from a report and shared by everything it is injected into
+type Summary struct {
+	First int32
+}
+
+// Store is bound to MemoryStore
+type Store interface {
+	Name() string
+}
+
+// MemoryStore is the implementation of Store
+type MemoryStore struct {
+	inject embeds.Inject
+}
+
+// Name returns the name of the store
+func (m *MemoryStore) Name() string {
+	return "memory"
+}
+
+// Dashboard receives its dependencies wrapped, so nothing is created until
+// the dashboard asks for it
+type Dashboard struct {
+	inject embeds.Inject
+
+	Report    inject.Lazy[*Report]
+	NewReport inject.Provider[*Report]
+	Store     inject.Provider[Store]
+
+	Theme         inject.Optional[Theme]
+	OptionalStore inject.Optional[Store]
+	Title         Title
+}
+
+// Theme is not bound by any module, so it can only be injected as an Optional
+type Theme string
+
+// Title is provided from optional values
+type Title string
+
+// Parent and Child depend on each other. The cycle is broken by the Lazy.
+type Parent struct {
+	inject embeds.Inject
+	Child  *Child
+}
+
+// Child lazily refers to a Parent
+type Child struct {
+	inject embeds.Inject
+	Parent inject.Lazy[*Parent]
+}
+
+// Registry is a singleton that loads its plugins when it is initialized. Since every
+// plugin depends on the registry, loading them while the registry is created fails.
+type Registry struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+	Plugins   inject.Provider[*Plugin]
+}
+
+// Init loads a plugin
+func (r *Registry) Init() error {
+	_, err := r.Plugins.Get()
+	return err
+}
+
+// Monitor is a singleton that waits for a goroutine when it is initialized. The goroutine
+// gets the monitor through a Provider, which fails while the monitor is created.
+type Monitor struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+	Self      inject.Provider[*Monitor]
+}
+
+// Init waits for the goroutine that gets the monitor
+func (m *Monitor) Init() error {
+	errs := make(chan error)
+	go func() {
+		_, err := m.Self.Get()
+		errs <- err
+	}()
+
+	return <-errs
+}
+
+// Plugin belongs to the registry
+type Plugin struct {
+	inject   embeds.Inject
+	Registry *Registry
+}
+
+// Session depends on the page it shows and the user of the request component
+type Session struct {
+	inject embeds.Inject
+	Page   *Page
+	User   User
+}
+
+// Page lazily refers to the Session that shows it
+type Page struct {
+	inject  embeds.Inject
+	Session inject.Lazy[*Session]
+}
+
+// User is provided by the request component
+type User string
+
+// ReportModule provides reports and counts how many were created
+type ReportModule struct {
+	provided embeds.ProvidedModule
+	Created  int32
+}
+
+// ProvidesReport creates a new report
+func (r *ReportModule) ProvidesReport() *Report {
+	return &Report{Number: atomic.AddInt32(&r.Created, 1)}
+}
+
+// ProvidesSummary summarizes the first report. The module is not a singleton, but the
+// summary is only created once per component.
+//
+//di:singleton
+func (r *ReportModule) ProvidesSummary(report *Report) *Summary {
+	return &Summary{First: report.Number}
+}
+
+// ProvidesTitle provides the title of the dashboard
+func (r *ReportModule) ProvidesTitle(theme inject.Optional[Theme], store inject.Optional[Store]) Title {
+	title := "Dashboard"
+	if theme, ok := theme.Get(); ok {
+		title = title + " (" + string(theme) + ")"
+	}
+
+	if store, ok := store.Get(); ok {
+		title = title + " from " + store.Name()
+	}
+
+	return Title(title)
+}
+
+// StoreModule binds Store to MemoryStore
+type StoreModule interface {
+	BindsStore(impl *MemoryStore) Store
+}
+
+// RequestModule provides the user of a request
+type RequestModule struct {
+	provided embeds.ProvidedModule
+	User     User
+}
+
+// ProvidesUser provides the user of the request
+func (r *RequestModule) ProvidesUser() User {
+	return r.User
+}
+
+// WrappersDefinition defines the WrappersComponent
+type WrappersDefinition interface {
+	Modules() (*ReportModule, StoreModule, RequestDefinition)
+	Target() WrappersComponent
+}
+
+// WrappersComponent can return wrapped types as well
+type WrappersComponent interface {
+	GetDashboard() (*Dashboard, error)
+	GetParent() (*Parent, error)
+	GetReportProvider() inject.Provider[*Report]
+	GetRegistry() (*Registry, error)
+	GetMonitor() (*Monitor, error)
+	GetSummary() (*Summary, error)
+	NewRequestComponent(module *RequestModule) RequestComponent
+}
+
+// RequestDefinition defines a subcomponent. Sessions and pages are created by the
+// subcomponent, since sessions depend on the user of the request.
+type RequestDefinition interface {
+	Modules() *RequestModule
+	Target() RequestComponent
+}
+
+// RequestComponent returns the session and its page
+type RequestComponent interface {
+	GetSession() (*Session, error)
+	GetPage() (*Page, error)
+}
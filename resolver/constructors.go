@@ -0,0 +1,100 @@
+package resolver
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+
+	"github.com/dimes/dihedral/typeutil"
+)
+
+const (
+	constructorDirective = "constructor"
+)
+
+// moduleConstructors returns the providers for the constructor functions listed by
+// `//di:constructor Function` directives in the doc comment of the given module
+func moduleConstructors(fileSet *token.FileSet, module *types.Named) ([]*ModuleResolvedType, error) {
+	pkgs, err := typeutil.LoadPackages(fileSet, module.Obj().Pkg().Path())
+	if err != nil {
+		return nil, err
+	}
+
+	var constructors []*ModuleResolvedType
+	for _, pkg := range pkgs {
+		for _, directive := range typeutil.Directives(typeutil.TypeDoc(pkg, module)) {
+			position := fileSet.Position(directive.Pos)
+			if directive.Name != constructorDirective {
+				return nil, fmt.Errorf("Unknown directive //di:%s on %s at %s",
+					directive.Name, typeLabel(module), position)
+			}
+
+			function, err := constructorFunc(fileSet, pkg.Types, directive)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid constructor %q on %s at %s: %s",
+					directive.Args, typeLabel(module), position, err)
+			}
+
+			constructor, err := newModuleResolvedType(nil, function)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid constructor %q on %s at %s: %s",
+					directive.Args, typeLabel(module), position, err)
+			}
+
+			constructors = append(constructors, constructor)
+		}
+	}
+
+	return constructors, nil
+}
+
+// constructorFunc returns the package-level function referenced by the given directive.
+// The function is either declared in the given package, or is qualified by the name of a
+// package imported by the file that contains the directive.
+func constructorFunc(fileSet *token.FileSet, pkg *types.Package, directive *typeutil.Directive) (*types.Func, error) {
+	expr, err := parser.ParseExpr(directive.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	var name *ast.Ident
+	switch typed := expr.(type) {
+	case *ast.Ident:
+		name = typed
+	case *ast.SelectorExpr:
+		if _, ok := typed.X.(*ast.Ident); !ok {
+			return nil, fmt.Errorf("Expected a function or a function of an imported package")
+		}
+		name = typed.Sel
+	default:
+		return nil, fmt.Errorf("Expected a function or a function of an imported package")
+	}
+
+	info := &types.Info{
+		Uses: make(map[*ast.Ident]types.Object),
+	}
+	if err := types.CheckExpr(fileSet, pkg, directive.Pos, expr, info); err != nil {
+		// The positions of the parsed expression do not refer to the file of the directive
+		if typeErr, ok := err.(types.Error); ok {
+			return nil, fmt.Errorf("%s", typeErr.Msg)
+		}
+		return nil, err
+	}
+
+	function, ok := info.Uses[name].(*types.Func)
+	if !ok || function.Type().(*types.Signature).Recv() != nil {
+		return nil, fmt.Errorf("Expected a package-level function")
+	}
+
+	if !function.Exported() {
+		return nil, fmt.Errorf("Function must be exported")
+	}
+
+	if function.Type().(*types.Signature).TypeParams().Len() > 0 {
+		return nil, fmt.Errorf("Generic functions cannot be constructors")
+	}
+
+	return function, nil
+}
@@ -0,0 +1,124 @@
+package resolver
+
+import (
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// cycleStep is a type on the current path of the cycle search, together with the
+// position of the field or parameter it was injected through
+type cycleStep struct {
+	label string
+	pos   token.Pos
+}
+
+// validateCycles checks that no type depends on itself. Dependencies injected through
+// a Lazy or Provider are not created with the type, so they do not form a cycle. The
+// components are the component being validated and all of its parents.
+func validateCycles(fileSet *token.FileSet, components []*ResolveResult) error {
+	component := components[len(components)-1]
+	done := make(map[string]struct{})
+	for _, target := range component.Targets {
+		dependency := newDependency(target.Type, target.Qualifier, token.NoPos)
+		if dependency.wrapped {
+			continue
+		}
+
+		if err := validateCyclesOf(
+			fileSet,
+			components,
+			dependency.rawType,
+			dependency.qualifier,
+			token.NoPos,
+			nil,
+			make(map[string]int),
+			done,
+		); err != nil {
+			return errors.Wrapf(err, "Error validating %s", target.MethodName)
+		}
+	}
+
+	for _, subcomponent := range component.Subcomponents {
+		subcomponents := append(append([]*ResolveResult{}, components...), subcomponent.Result)
+		if err := validateCycles(fileSet, subcomponents); err != nil {
+			return errors.Wrapf(err, "Error validating subcomponent %s", subcomponent.MethodName)
+		}
+	}
+
+	return nil
+}
+
+// validateCyclesOf walks the dependencies of the given type depth first. The path contains
+// the types leading to this type, and onPath maps the IDs of those types to their index in
+// the path. Types in done have been fully walked without finding a cycle.
+func validateCyclesOf(
+	fileSet *token.FileSet,
+	components []*ResolveResult,
+	rawType types.Type,
+	qualifier string,
+	pos token.Pos,
+	path []*cycleStep,
+	onPath map[string]int,
+	done map[string]struct{},
+) error {
+	node, err := newDependencyNode(components, rawType, qualifier)
+	if err != nil || node == nil {
+		return err
+	}
+
+	id := node.id
+	if _, ok := done[id]; ok {
+		return nil
+	}
+
+	path = append(path, &cycleStep{label: node.label, pos: pos})
+	if start, ok := onPath[id]; ok {
+		return cycleError(fileSet, path[start:])
+	}
+
+	onPath[id] = len(path) - 1
+	for _, dependency := range node.dependencies {
+		if dependency.wrapped {
+			continue
+		}
+
+		if err := validateCyclesOf(
+			fileSet,
+			components,
+			dependency.rawType,
+			dependency.qualifier,
+			dependency.pos,
+			path,
+			onPath,
+			done,
+		); err != nil {
+			return err
+		}
+	}
+	delete(onPath, id)
+
+	done[id] = struct{}{}
+	return nil
+}
+
+// cycleError describes the given cycle, whose first and last steps are the same type
+func cycleError(fileSet *token.FileSet, cycle []*cycleStep) error {
+	labels := make([]string, 0, len(cycle))
+	for _, step := range cycle {
+		labels = append(labels, step.label)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Dependency cycle: " + strings.Join(labels, " -> "))
+	for i := 1; i < len(cycle); i++ {
+		builder.WriteString("\n\t" + cycle[i-1].label + " -> " + cycle[i].label)
+		if cycle[i].pos.IsValid() && fileSet != nil {
+			builder.WriteString(" at " + fileSet.Position(cycle[i].pos).String())
+		}
+	}
+
+	return errors.New(builder.String())
+}
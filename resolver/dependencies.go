@@ -0,0 +1,225 @@
+package resolver
+
+import (
+	"go/token"
+	"go/types"
+
+	"github.com/dimes/dihedral/typeutil"
+	"github.com/pkg/errors"
+)
+
+// dependencyNode is a type in the dependency graph of a component
+type dependencyNode struct {
+	id            string        // ID of the type after applying bindings
+	typeName      string        // Human readable name of the type after applying bindings
+	label         string        // Human readable name, including the binding
+	scope         *types.Named  // Scope of the type, or nil
+	providerIndex int           // Index of the component providing the type, or -1
+	cached        bool          // True if the type is a singleton or scoped
+	param         bool          // True if the type is a parameter of target methods
+	dependencies  []*dependency // The types this type is created from
+}
+
+// dependency is an edge in the dependency graph
+type dependency struct {
+	rawType   types.Type // The injected type, without Lazy or Provider wrappers
+	qualifier string     // Qualifier of the field, or empty
+	pos       token.Pos  // Position of the field or parameter
+	wrapped   bool       // True if injected through a Lazy or Provider
+}
+
+// newDependencyNode returns the node for the given type with the given qualifier, or nil
+// if the type is neither named, a pointer to a named type, provided or a multibinding
+func newDependencyNode(
+	components []*ResolveResult,
+	rawType types.Type,
+	qualifier string,
+) (*dependencyNode, error) {
+	// Parameters of target methods are passed to the component
+	if qualifier == "" {
+		if paramType, index := lookupParam(components, typeutil.TypeID(rawType)); paramType != nil {
+			label := typeLabel(paramType)
+			return &dependencyNode{
+				id:            typeutil.TypeID(rawType),
+				typeName:      label,
+				label:         label,
+				providerIndex: index,
+				param:         true,
+			}, nil
+		}
+	}
+
+	name := namedFromType(rawType)
+	if name == nil {
+		return newUnnamedNode(components, rawType, qualifier), nil
+	}
+
+	label := qualifiedLabel(name, qualifier)
+	if binding := lookupBinding(components, typeutil.QualifiedID(name, qualifier)); binding != nil {
+		label = label + "(bound " + typeLabel(binding) + ")"
+		name = binding
+		qualifier = ""
+	}
+
+	node := &dependencyNode{
+		id:            typeutil.QualifiedID(name, qualifier),
+		typeName:      qualifiedLabel(name, qualifier),
+		label:         label,
+		providerIndex: -1,
+	}
+
+	// Instances are passed to the constructor of the component, so they depend on nothing
+	if instance, index := lookupInstance(components, node.id); instance != nil {
+		node.providerIndex = index
+	} else if provider, index := lookupProvider(components, node.id); provider != nil {
+		node.scope = provider.Scope
+		node.providerIndex = index
+		node.cached = provider.IsSingleton || provider.Scope != nil
+		node.dependencies = providerDependencies(provider)
+	} else if qualifier == "" {
+		fields, injectable, err := injectedFields(components, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error getting fields of %s", node.typeName)
+		}
+
+		if targetStruct, ok := name.Underlying().(*types.Struct); ok && injectable {
+			node.scope = typeutil.GetMarkedFieldType(targetStruct, scopeType)
+			node.cached = node.scope != nil || typeutil.HasFieldOfType(targetStruct, singletonType)
+		}
+
+		// Assisted fields are passed to the assisted factory of the struct
+		for _, field := range fields {
+			if field.IsAssisted {
+				continue
+			}
+
+			node.dependencies = append(node.dependencies, newDependency(field.Type(), field.Qualifier, field.Pos()))
+		}
+	}
+
+	return node, nil
+}
+
+// newUnnamedNode returns the node for a type that is not named. The type is either
+// provided by a provider method or an instance or, for slices and maps, collected from
+// multibindings.
+// Functions that are not provided are assisted factories.
+func newUnnamedNode(components []*ResolveResult, rawType types.Type, qualifier string) *dependencyNode {
+	id := typeutil.QualifiedID(rawType, qualifier)
+	label := qualifiedLabel(rawType, qualifier)
+	if instance, index := lookupInstance(components, id); instance != nil {
+		return &dependencyNode{
+			id:            id,
+			typeName:      label,
+			label:         label,
+			providerIndex: index,
+		}
+	}
+
+	provider, index := lookupProvider(components, id)
+	if provider == nil {
+		switch rawType.(type) {
+		case *types.Slice, *types.Map:
+			return newMultibindingNode(components, rawType)
+		case *types.Signature:
+			return newAssistedNode(rawType)
+		default:
+			return nil
+		}
+	}
+
+	return &dependencyNode{
+		id:            id,
+		typeName:      label,
+		label:         label,
+		scope:         provider.Scope,
+		providerIndex: index,
+		cached:        provider.IsSingleton || provider.Scope != nil,
+		dependencies:  providerDependencies(provider),
+	}
+}
+
+// newMultibindingNode returns the node for a slice or map, which depends on all of its
+// contributions. It is created by the last component that contributes to it.
+func newMultibindingNode(components []*ResolveResult, multibindingType types.Type) *dependencyNode {
+	id, ok := typeutil.IDFromType(multibindingType)
+	if !ok {
+		return nil
+	}
+
+	label := typeLabel(multibindingType)
+	node := &dependencyNode{
+		id:            id,
+		typeName:      label,
+		label:         label,
+		providerIndex: -1,
+	}
+
+	contributions, indices := lookupMultibindings(components, id)
+	for i, contribution := range contributions {
+		node.providerIndex = maxInt(node.providerIndex, indices[i])
+		if contribution.Provider != nil {
+			node.dependencies = append(node.dependencies, providerDependencies(contribution.Provider)...)
+		} else {
+			node.dependencies = append(node.dependencies, newDependency(contribution.Binding, "", contribution.Pos))
+		}
+	}
+
+	return node
+}
+
+// newAssistedNode returns the node for an assisted factory, or nil if the given function
+// is not one. The factory depends on the struct it creates, but only creates it when it
+// is called, like an inject.Provider.
+func newAssistedNode(factoryType types.Type) *dependencyNode {
+	name := typeutil.AssistedStruct(factoryType)
+	if name == nil {
+		return nil
+	}
+
+	label := typeLabel(factoryType)
+	return &dependencyNode{
+		id:            typeutil.TypeID(factoryType),
+		typeName:      label,
+		label:         label,
+		providerIndex: -1,
+		dependencies: []*dependency{{
+			rawType: types.NewPointer(name),
+			pos:     name.Obj().Pos(),
+			wrapped: true,
+		}},
+	}
+}
+
+func providerDependencies(provider *ModuleResolvedType) []*dependency {
+	dependencies := make([]*dependency, 0)
+	signature := provider.Method.Type().(*types.Signature)
+	for i := 0; i < signature.Params().Len(); i++ {
+		param := signature.Params().At(i)
+		dependencies = append(dependencies, newDependency(param.Type(), "", param.Pos()))
+	}
+
+	return dependencies
+}
+
+func newDependency(rawType types.Type, qualifier string, pos token.Pos) *dependency {
+	// Optional values are created with the type that depends on them, so only
+	// Lazy and Provider are treated as wrapped
+	wrapped := false
+	for {
+		unwrapped, wrapper := typeutil.UnwrapType(rawType)
+		if wrapper == nil {
+			break
+		}
+
+		wrapped = wrapped || !typeutil.IsOptional(wrapper)
+		rawType = unwrapped
+	}
+
+	return &dependency{
+		rawType:   rawType,
+		qualifier: qualifier,
+		pos:       pos,
+		wrapped:   wrapped,
+	}
+}
@@ -0,0 +1,158 @@
+package resolver
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"github.com/dimes/dihedral/typeutil"
+	"github.com/pkg/errors"
+)
+
+// Injectable is a struct without the embeds.Inject marker, e.g. from a library, that
+// a binding module declares injectable with a method like `InjectsClient() *lib.Client`.
+// The exported fields of the struct are injected as if it had the marker.
+type Injectable struct {
+	Name   *types.Named              // The injectable struct
+	Fields []*typeutil.InjectedField // The injected fields of the struct
+}
+
+// newInjectable returns the injectable declared by the given binding module method. The
+// method returns a pointer to the struct and can take a companion struct, whose fields
+// override the `di` tags of the fields of the injectable with the same names.
+func newInjectable(module *types.Named, method *types.Func) (*Injectable, error) {
+	signature := method.Type().(*types.Signature)
+	label := typeLabel(module) + "." + method.Name()
+	if signature.Params().Len() > 1 || signature.Results().Len() != 1 {
+		return nil, fmt.Errorf("Expected %s to have at most one parameter and one result", label)
+	}
+
+	name := namedFromType(signature.Results().At(0).Type())
+	if name == nil {
+		return nil, fmt.Errorf("Expected %s to return a pointer to a struct", label)
+	}
+
+	targetStruct, ok := name.Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("Expected %s to return a pointer to a struct", label)
+	}
+
+	if typeutil.HasFieldOfType(targetStruct, injectType) {
+		return nil, fmt.Errorf("%s is already marked with embeds.Inject", typeLabel(name))
+	}
+
+	fieldVars := make([]*types.Var, targetStruct.NumFields())
+	tags := make([]string, targetStruct.NumFields())
+	fieldIndices := make(map[string]int)
+	for i := 0; i < targetStruct.NumFields(); i++ {
+		fieldVars[i] = targetStruct.Field(i)
+		tags[i] = targetStruct.Tag(i)
+		fieldIndices[fieldVars[i].Name()] = i
+	}
+
+	if signature.Params().Len() == 1 {
+		companion, ok := signature.Params().At(0).Type().Underlying().(*types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("Expected the parameter of %s to be a struct", label)
+		}
+
+		for i := 0; i < companion.NumFields(); i++ {
+			field := companion.Field(i)
+			index, ok := fieldIndices[field.Name()]
+			if !ok || !fieldVars[index].Exported() ||
+				!types.Identical(field.Type(), fieldVars[index].Type()) {
+				return nil, fmt.Errorf("Field %s of the parameter of %s does not match an exported field of %s",
+					field.Name(), label, typeLabel(name))
+			}
+
+			tags[index] = companion.Tag(i)
+		}
+	}
+
+	fields, err := typeutil.InjectedFields(types.NewStruct(fieldVars, tags))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting fields of %s", typeLabel(name))
+	}
+
+	return &Injectable{
+		Name:   name,
+		Fields: fields,
+	}, nil
+}
+
+// validateInjectableDirectives checks that the given method, which declares a struct
+// injectable, has no directives. Injectables cannot be qualified or contributed.
+func validateInjectableDirectives(fileSet *token.FileSet, module *types.Named, methodName string) error {
+	pkgs, err := typeutil.LoadPackages(fileSet, module.Obj().Pkg().Path())
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range pkgs {
+		for _, directive := range typeutil.Directives(typeutil.MethodDoc(pkg, module, methodName)) {
+			return fmt.Errorf("Unexpected directive //di:%s on %s.%s at %s",
+				directive.Name, typeLabel(module), methodName, fileSet.Position(directive.Pos))
+		}
+	}
+
+	return nil
+}
+
+// injectedFields returns the fields, or constructor parameters, that are injected into the
+// given struct, and false if the struct is neither marked with embeds.Inject nor declared
+// injectable by a module of the given components
+func injectedFields(
+	components []*ResolveResult,
+	name *types.Named,
+) ([]*typeutil.InjectedField, bool, error) {
+	if injectable := lookupInjectable(components, typeutil.IDFromNamed(name)); injectable != nil {
+		return injectable.Fields, true, nil
+	}
+
+	targetStruct, ok := name.Underlying().(*types.Struct)
+	if !ok || !typeutil.HasFieldOfType(targetStruct, injectType) {
+		return nil, false, nil
+	}
+
+	// Structs with a constructor are created from the parameters of the constructor
+	constructor, err := typeutil.Constructor(name, targetStruct, injectType)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if constructor != nil {
+		return typeutil.ConstructorParams(constructor), true, nil
+	}
+
+	fields, err := typeutil.InjectedFields(targetStruct)
+	return fields, true, err
+}
+
+// validateInjectables checks that no struct declared injectable by the given component
+// or its ancestors is also provided by a provider method
+func validateInjectables(components []*ResolveResult) error {
+	component := components[len(components)-1]
+	for id := range component.Injectables {
+		if isProvided(components, id) {
+			return fmt.Errorf("%s is both provided and declared injectable", id)
+		}
+	}
+
+	for id := range component.Providers {
+		if lookupInjectable(components, id) != nil {
+			return fmt.Errorf("%s is both provided and declared injectable", id)
+		}
+	}
+
+	return nil
+}
+
+func lookupInjectable(components []*ResolveResult, id string) *Injectable {
+	for _, component := range components {
+		if injectable := component.Injectables[id]; injectable != nil {
+			return injectable
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package resolver
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/dimes/dihedral/typeutil"
+)
+
+// InstanceResolvedType represents a type whose instance is passed to the constructor
+// of the generated component
+type InstanceResolvedType struct {
+	Type  types.Type   // Type of the instance
+	Name  *types.Named // Name of the type, or nil if the type is not named
+	Index int          // Index of the instance in the results of the Instances() method
+}
+
+// DebugInfo implements ResolvedType DebugInfo
+func (i *InstanceResolvedType) DebugInfo() string {
+	return fmt.Sprintf("Instance: %d, type: %+v", i.Index, i.Type)
+}
+
+// getInstancesFromInterface returns the instances declared by the results of the
+// Instances() method of a definition, or nil if the definition has no Instances() method
+func getInstancesFromInterface(interfaceType *types.Interface) ([]*InstanceResolvedType, error) {
+	instancesMethod := typeutil.GetInterfaceMethod(interfaceType, instancesFunc)
+	if instancesMethod == nil {
+		return nil, nil
+	}
+
+	instancesSignature := instancesMethod.Type().(*types.Signature)
+	if instancesSignature.Params().Len() > 0 {
+		return nil, fmt.Errorf("Instances method %+v has arguments. Expected exactly 0", instancesMethod)
+	}
+
+	instances := make([]*InstanceResolvedType, 0)
+	for i := 0; i < instancesSignature.Results().Len(); i++ {
+		instanceType := instancesSignature.Results().At(i).Type()
+		if typeutil.IsContext(instanceType) {
+			return nil, fmt.Errorf("Expected %+v not to bind context.Context, which is passed to the component",
+				instancesMethod)
+		}
+
+		if _, wrapper := typeutil.UnwrapType(instanceType); wrapper != nil {
+			return nil, fmt.Errorf("Instance %+v of %+v cannot be a Lazy, Provider or Optional",
+				instanceType, instancesMethod)
+		}
+
+		instances = append(instances, &InstanceResolvedType{
+			Type:  instanceType,
+			Name:  namedFromType(instanceType),
+			Index: i,
+		})
+	}
+
+	return instances, nil
+}
+
+// lookupInstance returns the instance with the given ID, and the index of the component
+// whose constructor takes it
+func lookupInstance(components []*ResolveResult, id string) (*InstanceResolvedType, int) {
+	for i, component := range components {
+		if instance, ok := component.Providers[id].(*InstanceResolvedType); ok {
+			return instance, i
+		}
+	}
+
+	return nil, -1
+}
+
+// isProvided returns true if the type with the given ID is provided by a provider method
+// or passed to the constructor of one of the given components
+func isProvided(components []*ResolveResult, id string) bool {
+	provider, _ := lookupProvider(components, id)
+	instance, _ := lookupInstance(components, id)
+	return provider != nil || instance != nil
+}
@@ -0,0 +1,197 @@
+package resolver
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"github.com/dimes/dihedral/typeutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	setDirective = "set"
+	mapDirective = "map"
+)
+
+// Multibinding is the contribution of a single module method to a slice or a map. Every
+// field or parameter of type []T receives all contributions of type T to the slice, and
+// every field or parameter of type map[K]T receives all contributions with a key of type K.
+type Multibinding struct {
+	ElementType types.Type          // Type of the contributed element
+	Key         constant.Value      // Key of the element in the map, or nil for slices
+	KeyType     types.Type          // Type of the key, or nil for slices
+	Provider    *ModuleResolvedType // Provider method of the element, or nil for bindings
+	Binding     types.Type          // Implementation bound into the multibinding, or nil for providers
+	Pos         token.Pos           // Position of the directive on the contributing method
+}
+
+// Type returns the slice or map type this multibinding contributes to
+func (m *Multibinding) Type() types.Type {
+	if m.KeyType != nil {
+		return types.NewMap(m.KeyType, m.ElementType)
+	}
+
+	return types.NewSlice(m.ElementType)
+}
+
+// newMultibinding returns the multibinding declared by the directive on the given method,
+// or nil if the method is not a multibinding. The caller sets the Provider or Binding.
+func newMultibinding(
+	fileSet *token.FileSet,
+	receiver *types.Named,
+	methodName string,
+	elementType types.Type,
+) (*Multibinding, error) {
+	pkgs, err := typeutil.LoadPackages(fileSet, receiver.Obj().Pkg().Path())
+	if err != nil {
+		return nil, err
+	}
+
+	var result *Multibinding
+	for _, pkg := range pkgs {
+		for _, directive := range typeutil.Directives(typeutil.MethodDoc(pkg, receiver, methodName)) {
+			if directive.Name == nameDirective || directive.Name == singletonDirective {
+				continue
+			}
+
+			position := fileSet.Position(directive.Pos)
+			if result != nil {
+				return nil, fmt.Errorf("More than one multibinding directive on %s.%s at %s",
+					typeLabel(receiver), methodName, position)
+			}
+
+			result = &Multibinding{
+				ElementType: elementType,
+				Pos:         directive.Pos,
+			}
+
+			switch directive.Name {
+			case setDirective:
+				if directive.Args != "" {
+					return nil, fmt.Errorf("Unexpected key %s for set multibinding %s.%s at %s",
+						directive.Args, typeLabel(receiver), methodName, position)
+				}
+			case mapDirective:
+				if directive.Args == "" {
+					return nil, fmt.Errorf("Missing key for map multibinding %s.%s at %s",
+						typeLabel(receiver), methodName, position)
+				}
+
+				// Keys are evaluated in the scope of the file, so they can refer to imported constants
+				key, err := types.Eval(fileSet, pkg.Types, directive.Pos, directive.Args)
+				if err != nil {
+					return nil, errors.Wrapf(err, "Error evaluating key of %s.%s at %s",
+						typeLabel(receiver), methodName, position)
+				}
+
+				if key.Value == nil {
+					return nil, fmt.Errorf("Key %s of %s.%s at %s is not a constant",
+						directive.Args, typeLabel(receiver), methodName, position)
+				}
+
+				switch key.Value.Kind() {
+				case constant.String, constant.Int, constant.Bool:
+				default:
+					return nil, fmt.Errorf("Key %s of %s.%s at %s must be a string, integer or boolean",
+						directive.Args, typeLabel(receiver), methodName, position)
+				}
+
+				result.Key = key.Value
+				result.KeyType = types.Default(key.Type)
+			default:
+				return nil, fmt.Errorf("Unknown directive //di:%s on %s.%s at %s",
+					directive.Name, typeLabel(receiver), methodName, position)
+			}
+
+			if _, ok := typeutil.IDFromType(result.Type()); !ok {
+				return nil, fmt.Errorf("%s.%s at %s cannot be contributed to %+v",
+					typeLabel(receiver), methodName, position, result.Type())
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// multibindingID returns the ID of the slice or map the given multibinding contributes to
+func multibindingID(multibinding *Multibinding) string {
+	id, _ := typeutil.IDFromType(multibinding.Type())
+	return id
+}
+
+// validateMultibindingKeys checks that no two contributions to a map of the given
+// component and its ancestors have the same key
+func validateMultibindingKeys(fileSet *token.FileSet, components []*ResolveResult) error {
+	component := components[len(components)-1]
+	for id := range component.Multibindings {
+		contributions, _ := lookupMultibindings(components, id)
+		seen := make(map[string]*Multibinding)
+		for _, contribution := range contributions {
+			if contribution.Key == nil {
+				continue
+			}
+
+			key := contribution.Key.ExactString()
+			if previous, ok := seen[key]; ok {
+				return fmt.Errorf("Key %s of %s is contributed twice, at %s and at %s",
+					key, typeLabel(contribution.Type()), fileSet.Position(previous.Pos), fileSet.Position(contribution.Pos))
+			}
+			seen[key] = contribution
+		}
+	}
+
+	return nil
+}
+
+// validateProvidedMultibindings checks that no slice or map of the given component
+// and its ancestors is both provided by a provider method and contributed to
+func validateProvidedMultibindings(components []*ResolveResult) error {
+	component := components[len(components)-1]
+	for id := range component.Providers {
+		if contributions, _ := lookupMultibindings(components, id); len(contributions) > 0 {
+			return fmt.Errorf("%s is both provided and contributed to", id)
+		}
+	}
+
+	for id := range component.Multibindings {
+		if isProvided(components, id) {
+			return fmt.Errorf("%s is both provided and contributed to", id)
+		}
+	}
+
+	return nil
+}
+
+// sortMultibindings orders the contributions to every multibinding by the position
+// of the contributing methods
+func sortMultibindings(fileSet *token.FileSet, multibindings map[string][]*Multibinding) {
+	for _, contributions := range multibindings {
+		sort.SliceStable(contributions, func(i, j int) bool {
+			first := fileSet.Position(contributions[i].Pos)
+			second := fileSet.Position(contributions[j].Pos)
+			if first.Filename != second.Filename {
+				return first.Filename < second.Filename
+			}
+
+			return first.Offset < second.Offset
+		})
+	}
+}
+
+// lookupMultibindings returns the contributions to the given slice from all components,
+// together with the index of the contributing component
+func lookupMultibindings(components []*ResolveResult, id string) ([]*Multibinding, []int) {
+	var contributions []*Multibinding
+	var indices []int
+	for i, component := range components {
+		for _, contribution := range component.Multibindings[id] {
+			contributions = append(contributions, contribution)
+			indices = append(indices, i)
+		}
+	}
+
+	return contributions, indices
+}
@@ -0,0 +1,202 @@
+package resolver
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/dimes/dihedral/typeutil"
+	"github.com/pkg/errors"
+)
+
+// paramVisit is a type that has been visited while validating the parameters of a
+// target, either with or without a cached type on the path leading to it
+type paramVisit struct {
+	id     string
+	cached bool
+}
+
+// validateTargetParams checks the parameters of a target method, and returns true if
+// one of them is the context. Every other parameter has to be of a different type.
+func validateTargetParams(signature *types.Signature) (bool, error) {
+	if signature.Variadic() {
+		return false, fmt.Errorf("Expected %+v not to be variadic", signature)
+	}
+
+	hasContext := false
+	seen := make(map[string]struct{})
+	for i := 0; i < signature.Params().Len(); i++ {
+		param := signature.Params().At(i)
+		if typeutil.IsContext(param.Type()) {
+			if hasContext {
+				return false, fmt.Errorf("Expected %+v to take at most one context.Context", signature)
+			}
+
+			hasContext = true
+			continue
+		}
+
+		if _, wrapper := typeutil.UnwrapType(param.Type()); wrapper != nil {
+			return false, fmt.Errorf("Parameter %+v cannot be a Lazy, Provider or Optional", param)
+		}
+
+		id := typeutil.TypeID(param.Type())
+		if _, ok := seen[id]; ok {
+			return false, fmt.Errorf("Expected %+v to take at most one parameter of type %s", signature, id)
+		}
+		seen[id] = struct{}{}
+	}
+
+	return hasContext, nil
+}
+
+// targetParams returns the types of the parameters of the given targets, other than the
+// context. Parameters cannot be provided, bound or declared injectable by the given
+// components, and parameters of the same type have to be of identical types.
+func targetParams(components []*ResolveResult, targets []*InjectionTarget) (map[string]types.Type, error) {
+	params := make(map[string]types.Type)
+	for _, target := range targets {
+		for _, param := range target.Params {
+			if typeutil.IsContext(param.Type()) {
+				continue
+			}
+
+			id := typeutil.TypeID(param.Type())
+			if paramType, ok := params[id]; ok && !types.Identical(paramType, param.Type()) {
+				return nil, fmt.Errorf("Parameter %+v of %s has a different type than other parameters of type %s",
+					param, target.MethodName, id)
+			}
+
+			multibindings, _ := lookupMultibindings(components, id)
+			if isProvided(components, id) || lookupBinding(components, id) != nil ||
+				lookupInjectable(components, id) != nil || len(multibindings) > 0 {
+				return nil, fmt.Errorf("Parameter %+v of %s is already bound by the component", param, target.MethodName)
+			}
+
+			params[id] = param.Type()
+		}
+	}
+
+	return params, nil
+}
+
+// validateParams checks that every target only depends on the parameters it takes itself,
+// and that no singleton or scoped type depends on a parameter, since it would keep the
+// parameter of the first target that created it. The components are the component being
+// validated and all of its parents.
+func validateParams(components []*ResolveResult) error {
+	component := components[len(components)-1]
+	for _, target := range component.Targets {
+		params := make(map[string]struct{})
+		for _, param := range target.Params {
+			if !typeutil.IsContext(param.Type()) {
+				params[typeutil.TypeID(param.Type())] = struct{}{}
+			}
+		}
+
+		if err := validateParamsOf(
+			components,
+			target.Type,
+			target.Qualifier,
+			params,
+			nil,
+			"",
+			make(map[paramVisit]struct{}),
+		); err != nil {
+			return errors.Wrapf(err, "Error validating %s", target.MethodName)
+		}
+	}
+
+	for _, subcomponent := range component.Subcomponents {
+		subcomponents := append(append([]*ResolveResult{}, components...), subcomponent.Result)
+		if err := validateParams(subcomponents); err != nil {
+			return errors.Wrapf(err, "Error validating subcomponent %s", subcomponent.MethodName)
+		}
+	}
+
+	return nil
+}
+
+// validateParamsOf validates the given type and its dependencies. The path contains the
+// types leading to this type, and cached is the label of the first singleton or scoped
+// type on the path, or empty.
+func validateParamsOf(
+	components []*ResolveResult,
+	rawType types.Type,
+	qualifier string,
+	params map[string]struct{},
+	path []string,
+	cached string,
+	visited map[paramVisit]struct{},
+) error {
+	// Types injected through a Lazy or Provider are created with the parameters of the
+	// target that created the wrapper
+	rawType = typeutil.UnwrapAll(rawType)
+	node, err := newDependencyNode(components, rawType, qualifier)
+	if err != nil || node == nil {
+		return err
+	}
+
+	visit := paramVisit{id: node.id, cached: cached != ""}
+	if _, ok := visited[visit]; ok {
+		return nil
+	}
+	visited[visit] = struct{}{}
+
+	path = append(path, node.label)
+	if node.param {
+		if _, ok := params[node.id]; !ok {
+			return fmt.Errorf("%s is not a parameter of the target method: %s",
+				node.typeName, strings.Join(path, " -> "))
+		}
+
+		if cached != "" {
+			return fmt.Errorf("%s is a parameter of the target method and cannot be injected into %s, "+
+				"which is cached by the component: %s", node.typeName, cached, strings.Join(path, " -> "))
+		}
+
+		return nil
+	}
+
+	if node.cached && cached == "" {
+		cached = node.label
+	}
+
+	for _, dependency := range node.dependencies {
+		if err := validateParamsOf(
+			components,
+			dependency.rawType,
+			dependency.qualifier,
+			params,
+			path,
+			cached,
+			visited,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lookupParam returns the type of the parameters of target methods with the given ID, and
+// the index of the component whose targets take them
+func lookupParam(components []*ResolveResult, id string) (types.Type, int) {
+	for i, component := range components {
+		if paramType, ok := component.Params[id]; ok {
+			return paramType, i
+		}
+	}
+
+	return nil, -1
+}
+
+// tupleVars returns the variables of the given tuple
+func tupleVars(tuple *types.Tuple) []*types.Var {
+	vars := make([]*types.Var, tuple.Len())
+	for i := 0; i < tuple.Len(); i++ {
+		vars[i] = tuple.At(i)
+	}
+
+	return vars
+}
@@ -0,0 +1,56 @@
+package resolver
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"github.com/dimes/dihedral/typeutil"
+)
+
+const (
+	nameDirective = "name"
+)
+
+// methodQualifier returns the qualifier declared by a `//di:name qualifier` directive
+// on the given method, or an empty string if the method is not qualified
+func methodQualifier(fileSet *token.FileSet, receiver *types.Named, methodName string) (string, error) {
+	pkgs, err := typeutil.LoadPackages(fileSet, receiver.Obj().Pkg().Path())
+	if err != nil {
+		return "", err
+	}
+
+	qualifier := ""
+	for _, pkg := range pkgs {
+		for _, directive := range typeutil.Directives(typeutil.MethodDoc(pkg, receiver, methodName)) {
+			if directive.Name != nameDirective {
+				continue
+			}
+
+			position := fileSet.Position(directive.Pos)
+			if qualifier != "" {
+				return "", fmt.Errorf("More than one qualifier on %s.%s at %s",
+					typeLabel(receiver), methodName, position)
+			}
+
+			if !typeutil.IsQualifier(directive.Args) {
+				return "", fmt.Errorf("Qualifier %q of %s.%s at %s is not a valid identifier",
+					directive.Args, typeLabel(receiver), methodName, position)
+			}
+
+			qualifier = directive.Args
+		}
+	}
+
+	return qualifier, nil
+}
+
+// qualifiedLabel returns a short, human readable name for the given type with
+// the given qualifier
+func qualifiedLabel(rawType types.Type, qualifier string) string {
+	if qualifier == "" {
+		return typeLabel(rawType)
+	}
+
+	return typeLabel(rawType) + "(" + nameDirective + "=" + qualifier + ")"
+}
@@ -5,26 +5,36 @@ import (
 	"fmt"
 	"go/token"
 	"go/types"
+	"reflect"
 
+	"github.com/dimes/dihedral/embeds"
 	"github.com/dimes/dihedral/structs"
 	"github.com/dimes/dihedral/typeutil"
 	"github.com/pkg/errors"
-	"golang.org/x/tools/go/packages"
 )
 
 const (
-	modulesFunc = "Modules"
-	targetFunc  = "Target"
+	modulesFunc   = "Modules"
+	targetFunc    = "Target"
+	scopeFunc     = "Scope"
+	instancesFunc = "Instances"
+	closeFunc     = "Close"
+	startFunc     = "Start"
+	stopFunc      = "Stop"
 )
 
 var (
+	injectType         = reflect.TypeOf(embeds.Inject{})
+	singletonType      = reflect.TypeOf(embeds.Singleton{})
+	scopeType          = reflect.TypeOf(embeds.Scope{})
+	providedModuleType = reflect.TypeOf(embeds.ProvidedModule{})
+
 	reservedMethods = map[string]struct{}{
 		modulesFunc: struct{}{},
 	}
 )
 
 type resolutionNode struct {
-	parent   *resolutionNode
 	nodeType types.Type
 }
 
@@ -32,9 +42,14 @@ type resolutionNode struct {
 type InjectionTarget struct {
 	MethodName string
 	Type       types.Type
-	Name       *types.Named
+	Name       *types.Named // Name of the type, or nil if the type is not named
+	Qualifier  string       // Qualifier from the `//di:name` directive on the method, or empty
 	IsPointer  bool
 	HasError   bool
+	HasCleanup bool         // True if the method returns the function that cleans up the value
+	HasContext bool         // True if the method takes a context.Context
+	Params     []*types.Var // Parameters of the method, including the context
+	IsMembers  bool         // True if the method injects the fields of the struct passed to it
 }
 
 // ResolvedType is an interface that represents a type provided by
@@ -46,31 +61,55 @@ type ResolvedType interface {
 
 // ModuleResolvedType represents a type that has been resolved via a module.
 type ModuleResolvedType struct {
-	Module    *structs.Struct
-	Method    *types.Func
-	Name      *types.Named
-	IsPointer bool
-	HasError  bool
+	Module          *structs.Struct // Module declaring the method, or nil for constructor functions
+	Method          *types.Func     // Provider method of the module, or the constructor function
+	Type            types.Type      // Type returned by the method
+	Name            *types.Named    // Name of the returned type, or nil if the type is not named
+	Qualifier       string          // Qualifier from the `//di:name` directive on the method, or empty
+	IsPointer       bool
+	HasError        bool
+	HasCleanup      bool         // True if the method returns a cleanup function after the value
+	CleanupHasError bool         // True if the cleanup function returns an error
+	IsSingleton     bool         // True if the module or the method is marked as singleton
+	Scope           *types.Named // The scope the module is marked with, or nil
 }
 
 // DebugInfo implements ResolvedType DebugInfo
 func (m *ModuleResolvedType) DebugInfo() string {
-	return fmt.Sprintf("Module: %+v, method: %+v, type name: %+v, isPointer: %t",
-		m.Module, m.Method, m.Name, m.IsPointer)
+	return fmt.Sprintf("Module: %+v, method: %+v, type: %+v, qualifier: %q, isPointer: %t, isSingleton: %t, scope: %+v",
+		m.Module, m.Method, m.Type, m.Qualifier, m.IsPointer, m.IsSingleton, m.Scope)
 }
 
 // ResolveResult is the result of ResolveComponentModules
 type ResolveResult struct {
-	TargetInterfaceName string                  // Name of the Target interface
-	Targets             []*InjectionTarget      // List of injection targets
-	Providers           map[string]ResolvedType // Map of type to the provider of that type
-	Bindings            map[string]*types.Named // Map of interface to concrete type
+	TargetInterfaceName string                     // Name of the Target interface
+	TargetInterface     *types.Named               // The Target interface
+	Scope               *types.Named               // The scope of the component, or nil
+	Targets             []*InjectionTarget         // List of injection targets
+	Providers           map[string]ResolvedType    // Map of type to the provider of that type
+	Bindings            map[string]*types.Named    // Map of (qualified) interface to concrete type
+	Multibindings       map[string][]*Multibinding // Map of slice type to its contributions
+	Injectables         map[string]*Injectable     // Map of struct to its declaration as injectable
+	Params              map[string]types.Type      // Map of the types passed to target methods
+	Subcomponents       []*Subcomponent            // Components created by the Target interface
+}
+
+// Subcomponent is a component that is created by a factory method on the target
+// interface of its parent. A subcomponent can inject every type available to its
+// parent, in addition to the types from its own modules.
+type Subcomponent struct {
+	MethodName string            // Name of the factory method on the parent
+	Modules    []*structs.Struct // Provided modules that are parameters of the factory method
+	Result     *ResolveResult    // The resolved subcomponent definition
 }
 
 // ResolveComponentModules resolves the modules for the component interface.
 // The return types are:
 // - List of struct modules (used to provide concrete types)
 // - List of interface modules (used to bind interfaces to implementations)
+//
+// Definitions included in the modules of the component are resolved as
+// subcomponents.
 func ResolveComponentModules(
 	fileSet *token.FileSet,
 	componentInterface *structs.Interface,
@@ -78,12 +117,47 @@ func ResolveComponentModules(
 	*ResolveResult,
 	error,
 ) {
-	targetInterfaceName, targets, err := getTargetsFromInterface(componentInterface.Type)
+	result, err := resolveComponentModules(fileSet, componentInterface, nil)
 	if err != nil {
-		return nil, errors.Wrapf(err, "Error getting targets for %+v", componentInterface)
+		return nil, err
+	}
+
+	if err := validateCycles(fileSet, []*ResolveResult{result}); err != nil {
+		return nil, errors.Wrapf(err, "Error validating dependencies of %+v", componentInterface)
+	}
+
+	if err := validateScopes([]*ResolveResult{result}); err != nil {
+		return nil, errors.Wrapf(err, "Error validating scopes of %+v", componentInterface)
+	}
+
+	if err := validateParams([]*ResolveResult{result}); err != nil {
+		return nil, errors.Wrapf(err, "Error validating parameters of %+v", componentInterface)
+	}
+
+	return result, nil
+}
+
+func resolveComponentModules(
+	fileSet *token.FileSet,
+	componentInterface *structs.Interface,
+	ancestors []*ResolveResult,
+) (
+	*ResolveResult,
+	error,
+) {
+	scope, err := getScopeFromInterface(componentInterface.Type)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting scope for %+v", componentInterface)
 	}
 
-	stack, err := getNodesFromInterface(componentInterface.Type, nil)
+	for _, ancestor := range ancestors {
+		if scope != nil && ancestor.Scope != nil &&
+			typeutil.IDFromNamed(scope) == typeutil.IDFromNamed(ancestor.Scope) {
+			return nil, fmt.Errorf("Scope %+v is already used by a parent component", scope)
+		}
+	}
+
+	stack, err := getNodesFromInterface(componentInterface.Type)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error getting modules for %+v", componentInterface)
 	}
@@ -91,6 +165,26 @@ func ResolveComponentModules(
 	seen := make(map[string]struct{})
 	providers := make(map[string]ResolvedType)
 	bindings := make(map[string]*types.Named)
+
+	// Instances are passed to the constructor of the component, which subcomponents do not have
+	instances, err := getInstancesFromInterface(componentInterface.Type)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting instances for %+v", componentInterface)
+	}
+
+	if len(instances) > 0 && len(ancestors) > 0 {
+		return nil, fmt.Errorf("Subcomponent %+v cannot declare instances", componentInterface)
+	}
+
+	for _, instance := range instances {
+		if err := addProvider(providers, bindings, ancestors, typeutil.TypeID(instance.Type), instance); err != nil {
+			return nil, err
+		}
+	}
+
+	multibindings := make(map[string][]*Multibinding)
+	injectables := make(map[string]*Injectable)
+	subcomponentDefinitions := make([]*structs.Interface, 0)
 	for len(stack) > 0 {
 		node := stack[len(stack)-1]
 		stack = stack[:len(stack)-1]
@@ -108,7 +202,16 @@ func ResolveComponentModules(
 			}
 			seen[id] = struct{}{}
 
-			nodeModules, err := getNodesFromInterface(nodeInterface, node)
+			// Definitions have a Target() method and are resolved separately as subcomponents
+			if typeutil.GetInterfaceMethod(nodeInterface, targetFunc) != nil {
+				subcomponentDefinitions = append(subcomponentDefinitions, &structs.Interface{
+					Name: typedNode,
+					Type: nodeInterface,
+				})
+				continue
+			}
+
+			nodeModules, err := getNodesFromInterface(nodeInterface)
 			if err != nil {
 				return nil, errors.Wrapf(err, "Error getting dependencies for %+v", nodeInterface)
 			}
@@ -120,11 +223,36 @@ func ResolveComponentModules(
 				Type: nodeInterface,
 			}
 
-			moduleBindings, err := extractBindings(bindingInterface)
+			moduleBindings, moduleMultibindings, moduleInjectables, err := extractBindings(fileSet, bindingInterface)
 			if err != nil {
 				return nil, errors.Wrapf(err, "Error extracting bindings in %+v", nodeInterface)
 			}
 
+			for _, multibinding := range moduleMultibindings {
+				multibindingID := multibindingID(multibinding)
+				multibindings[multibindingID] = append(multibindings[multibindingID], multibinding)
+			}
+
+			for _, injectable := range moduleInjectables {
+				injectableID := typeutil.IDFromNamed(injectable.Name)
+				if injectables[injectableID] != nil || lookupInjectable(ancestors, injectableID) != nil {
+					return nil, fmt.Errorf("%s is declared injectable twice", injectableID)
+				}
+
+				injectables[injectableID] = injectable
+			}
+
+			constructors, err := moduleConstructors(fileSet, typedNode)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error getting constructors of %+v", typedNode)
+			}
+
+			for _, constructor := range constructors {
+				if err := addProvider(providers, bindings, ancestors, providerID(constructor), constructor); err != nil {
+					return nil, err
+				}
+			}
+
 			for id, boundStruct := range moduleBindings {
 				if _, ok := bindings[id]; ok {
 					return nil, fmt.Errorf("Binding %+v seen twice", id)
@@ -134,6 +262,10 @@ func ResolveComponentModules(
 					return nil, fmt.Errorf("Binding %+v seen twice", id)
 				}
 
+				if isBoundInAncestor(id, ancestors) {
+					return nil, fmt.Errorf("Binding %+v is already bound by a parent component", id)
+				}
+
 				bindings[id] = boundStruct
 			}
 		case *types.Pointer:
@@ -157,12 +289,18 @@ func ResolveComponentModules(
 				Name: namedNode,
 				Type: structNode,
 			}
-
-			config := &packages.Config{
-				Mode: packages.LoadSyntax,
+			isSingleton := typeutil.HasFieldOfType(structNode, singletonType)
+
+			// Scoped modules are cached on the component with the same scope, so
+			// they have to be included by that component
+			moduleScope := typeutil.GetMarkedFieldType(structNode, scopeType)
+			if moduleScope != nil && (scope == nil ||
+				typeutil.IDFromNamed(moduleScope) != typeutil.IDFromNamed(scope)) {
+				return nil, fmt.Errorf("Module %+v has scope %+v but is included in a component with scope %+v",
+					namedNode, moduleScope, scope)
 			}
 
-			pkgs, err := packages.Load(config, namedNode.Obj().Pkg().Path())
+			pkgs, err := typeutil.LoadPackages(fileSet, namedNode.Obj().Pkg().Path())
 			if err != nil {
 				return nil, errors.Wrapf(err, "Error loading %+v", namedNode)
 			}
@@ -196,60 +334,63 @@ func ResolveComponentModules(
 						continue
 					}
 
-					if signature.Results().Len() == 0 || signature.Results().Len() > 2 {
-						return nil, fmt.Errorf("Expected at most two results from %+v", signature)
+					qualifier, err := methodQualifier(fileSet, namedNode, funcDefinition.Name())
+					if err != nil {
+						return nil, err
 					}
 
-					hasError := false
-					if signature.Results().Len() == 2 {
-						errType, ok := signature.Results().At(1).Type().(*types.Named)
-						if !ok {
-							return nil, fmt.Errorf("Expected %+v to return an error", signature)
-						}
-
-						if errType.Obj().Pkg() != nil {
-							return nil, fmt.Errorf("Expected %+v to return an error", signature)
-						}
-
-						if errType.Obj().Name() != "error" {
-							return nil, fmt.Errorf("Expected %+v to return an error", signature)
-						}
+					// Single methods of a module that is not a singleton can be marked as singletons
+					isMethodSingleton, err := methodIsSingleton(fileSet, namedNode, funcDefinition.Name())
+					if err != nil {
+						return nil, err
+					}
 
-						hasError = true
+					if isMethodSingleton && moduleScope != nil {
+						return nil, fmt.Errorf("%s.%s cannot be marked as singleton, since its module has scope %s",
+							typeLabel(namedNode), funcDefinition.Name(), typeLabel(moduleScope))
 					}
 
-					result := signature.Results().At(0)
-					var resultName *types.Named
-
-					isPointer := false
-					switch resultType := result.Type().(type) {
-					case *types.Pointer:
-						isPointer = true
-						resultName = resultType.Elem().(*types.Named)
-					case *types.Named:
-						resultName = resultType
-					default:
-						return nil, fmt.Errorf("Result %+v is an unsupported type", result)
+					resolvedType, err := newModuleResolvedType(module, funcDefinition)
+					if err != nil {
+						return nil, err
 					}
+					resolvedType.Qualifier = qualifier
+					resolvedType.IsSingleton = isSingleton || isMethodSingleton
+					resolvedType.Scope = moduleScope
 
-					resultID := typeutil.IDFromNamed(resultName)
-					if _, ok := bindings[resultID]; ok {
-						return nil, fmt.Errorf("Binding %+v seen twice", resultID)
+					multibinding, err := newMultibinding(fileSet, namedNode, funcDefinition.Name(), resolvedType.Type)
+					if err != nil {
+						return nil, err
 					}
 
-					if _, ok := providers[resultID]; ok {
-						return nil, fmt.Errorf("Binding %+v seen twice", resultID)
+					if multibinding != nil {
+						if qualifier != "" {
+							return nil, fmt.Errorf("Multibinding %s.%s cannot be qualified",
+								typeLabel(namedNode), funcDefinition.Name())
+						}
+
+						multibinding.Provider = resolvedType
+						multibindingID := multibindingID(multibinding)
+						multibindings[multibindingID] = append(multibindings[multibindingID], multibinding)
+						continue
 					}
 
-					resolvedType := &ModuleResolvedType{
-						Module:    module,
-						Method:    funcDefinition,
-						Name:      resultName,
-						IsPointer: isPointer,
-						HasError:  hasError,
+					if err := addProvider(providers, bindings, ancestors, providerID(resolvedType), resolvedType); err != nil {
+						return nil, err
 					}
+				}
+			}
+
+			constructors, err := moduleConstructors(fileSet, namedNode)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error getting constructors of %+v", namedNode)
+			}
 
-					providers[resultID] = resolvedType
+			for _, constructor := range constructors {
+				constructor.IsSingleton = isSingleton
+				constructor.Scope = moduleScope
+				if err := addProvider(providers, bindings, ancestors, providerID(constructor), constructor); err != nil {
+					return nil, err
 				}
 			}
 		default:
@@ -257,46 +398,248 @@ func ResolveComponentModules(
 		}
 	}
 
-	return &ResolveResult{
-		TargetInterfaceName: targetInterfaceName,
-		Targets:             targets,
-		Providers:           providers,
-		Bindings:            bindings,
+	sortMultibindings(fileSet, multibindings)
+	result := &ResolveResult{
+		Scope:         scope,
+		Providers:     providers,
+		Bindings:      bindings,
+		Multibindings: multibindings,
+		Injectables:   injectables,
+	}
+
+	if err := validateMultibindingKeys(fileSet, append(append([]*ResolveResult{}, ancestors...), result)); err != nil {
+		return nil, errors.Wrapf(err, "Error resolving multibindings of %+v", componentInterface)
+	}
+
+	if err := validateProvidedMultibindings(append(append([]*ResolveResult{}, ancestors...), result)); err != nil {
+		return nil, errors.Wrapf(err, "Error resolving multibindings of %+v", componentInterface)
+	}
+
+	if err := validateInjectables(append(append([]*ResolveResult{}, ancestors...), result)); err != nil {
+		return nil, errors.Wrapf(err, "Error resolving injectables of %+v", componentInterface)
+	}
+
+	// Subcomponents are resolved with this component as an ancestor so
+	// that they cannot rebind types already bound by their parents
+	subcomponentResults := make(map[string]*ResolveResult)
+	for _, definition := range subcomponentDefinitions {
+		subcomponentResult, err := resolveComponentModules(
+			fileSet,
+			definition,
+			append(append([]*ResolveResult{}, ancestors...), result))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error resolving subcomponent %+v", definition.Name)
+		}
+
+		subcomponentID := typeutil.IDFromNamed(subcomponentResult.TargetInterface)
+		if _, ok := subcomponentResults[subcomponentID]; ok {
+			return nil, fmt.Errorf("Subcomponent %+v is defined twice", subcomponentID)
+		}
+		subcomponentResults[subcomponentID] = subcomponentResult
+	}
+
+	targetInterface, targets, subcomponents, err := getTargetsFromInterface(
+		fileSet,
+		componentInterface.Type,
+		subcomponentResults)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting targets for %+v", componentInterface)
+	}
+
+	// The component itself is injected wherever its target interface is
+	components := append(append([]*ResolveResult{}, ancestors...), result)
+	targetID := typeutil.IDFromNamed(targetInterface)
+	if isProvided(components, targetID) || lookupBinding(components, targetID) != nil {
+		return nil, fmt.Errorf("%s is the target interface of the component and cannot be provided or bound", targetID)
+	}
+
+	params, err := targetParams(components, targets)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting parameters of the targets of %+v", componentInterface)
+	}
+
+	result.TargetInterfaceName = targetInterface.Obj().Name()
+	result.TargetInterface = targetInterface
+	result.Targets = targets
+	result.Params = params
+	result.Subcomponents = subcomponents
+	return result, nil
+}
+
+// newModuleResolvedType returns the provider for the given module method, or for the
+// given constructor function if the module is nil
+func newModuleResolvedType(module *structs.Struct, function *types.Func) (*ModuleResolvedType, error) {
+	signature := function.Type().(*types.Signature)
+	results := signature.Results()
+	if results.Len() == 0 || results.Len() > 3 {
+		return nil, fmt.Errorf("Expected at most three results from %+v", signature)
+	}
+
+	// The value is optionally followed by a cleanup function and an error
+	hasCleanup := false
+	cleanupHasError := false
+	if results.Len() > 1 {
+		hasCleanup, cleanupHasError = cleanupSignature(results.At(1).Type())
+	}
+
+	hasError := false
+	if (results.Len() == 2 && !hasCleanup) || results.Len() == 3 {
+		if !typeutil.IsError(results.At(results.Len() - 1).Type()) {
+			return nil, fmt.Errorf("Expected %+v to return an error", signature)
+		}
+
+		hasError = true
+	}
+
+	if results.Len() == 3 && !hasCleanup {
+		return nil, fmt.Errorf("Expected %+v to return a cleanup function", signature)
+	}
+
+	// Types that are not named are identified by their full type
+	resultType := results.At(0).Type()
+	if typeutil.IsContext(resultType) {
+		return nil, fmt.Errorf("Expected %+v not to provide context.Context, which is passed to the component", signature)
+	}
+
+	resultName := namedFromType(resultType)
+	_, isPointer := resultType.(*types.Pointer)
+
+	return &ModuleResolvedType{
+		Module:          module,
+		Method:          function,
+		Type:            resultType,
+		Name:            resultName,
+		IsPointer:       isPointer && resultName != nil,
+		HasError:        hasError,
+		HasCleanup:      hasCleanup,
+		CleanupHasError: cleanupHasError,
 	}, nil
 }
 
+// cleanupSignature returns true if the given type is a cleanup function, i.e. either
+// `func()` or `func() error`, and whether the cleanup function returns an error
+func cleanupSignature(rawType types.Type) (bool, bool) {
+	signature, ok := rawType.(*types.Signature)
+	if !ok || signature.Params().Len() > 0 {
+		return false, false
+	}
+
+	switch {
+	case signature.Results().Len() == 0:
+		return true, false
+	case signature.Results().Len() == 1 && typeutil.IsError(signature.Results().At(0).Type()):
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// addProvider adds the given provider to the providers of a component, unless its type
+// is already bound or provided by the component or one of its ancestors
+func addProvider(
+	providers map[string]ResolvedType,
+	bindings map[string]*types.Named,
+	ancestors []*ResolveResult,
+	id string,
+	provider ResolvedType,
+) error {
+	if _, ok := bindings[id]; ok {
+		return fmt.Errorf("Binding %+v seen twice", id)
+	}
+
+	if _, ok := providers[id]; ok {
+		return fmt.Errorf("Binding %+v seen twice", id)
+	}
+
+	if isBoundInAncestor(id, ancestors) {
+		return fmt.Errorf("Binding %+v is already bound by a parent component", id)
+	}
+
+	providers[id] = provider
+	return nil
+}
+
+// providerID returns the ID of the type provided by the given provider
+func providerID(provider *ModuleResolvedType) string {
+	return typeutil.QualifiedID(provider.Type, provider.Qualifier)
+}
+
+func isBoundInAncestor(id string, ancestors []*ResolveResult) bool {
+	for _, ancestor := range ancestors {
+		if _, ok := ancestor.Providers[id]; ok {
+			return true
+		}
+
+		if _, ok := ancestor.Bindings[id]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getScopeFromInterface returns the scope returned by the Scope() method of a definition,
+// or nil if the definition has no Scope() method
+func getScopeFromInterface(interfaceType *types.Interface) (*types.Named, error) {
+	scopeMethod := typeutil.GetInterfaceMethod(interfaceType, scopeFunc)
+	if scopeMethod == nil {
+		return nil, nil
+	}
+
+	scopeSignature := scopeMethod.Type().(*types.Signature)
+	if scopeSignature.Params().Len() > 0 || scopeSignature.Results().Len() != 1 {
+		return nil, fmt.Errorf("Expected scope method %+v to have no arguments and one result", scopeMethod)
+	}
+
+	scope, ok := scopeSignature.Results().At(0).Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("Expected result of %+v to be a scope", scopeMethod)
+	}
+
+	scopeStruct, ok := scope.Underlying().(*types.Struct)
+	if !ok || !typeutil.HasFieldOfType(scopeStruct, scopeType) {
+		return nil, fmt.Errorf("Expected %+v to be a struct marked with embeds.Scope", scope)
+	}
+
+	return scope, nil
+}
+
 func getTargetsFromInterface(
+	fileSet *token.FileSet,
 	interfaceType *types.Interface,
+	subcomponentResults map[string]*ResolveResult,
 ) (
-	string,
+	*types.Named,
 	[]*InjectionTarget,
+	[]*Subcomponent,
 	error,
 ) {
 	targetMethod := typeutil.GetInterfaceMethod(interfaceType, targetFunc)
 	if targetMethod == nil {
-		return "", nil, fmt.Errorf("%+v has no Target() method", interfaceType)
+		return nil, nil, nil, fmt.Errorf("%+v has no Target() method", interfaceType)
 	}
 
 	targetSignature := targetMethod.Type().(*types.Signature)
 	if targetSignature.Params().Len() > 0 {
-		return "", nil, fmt.Errorf("Target method %+v has arguments. Expected exactly 0", targetMethod)
+		return nil, nil, nil, fmt.Errorf("Target method %+v has arguments. Expected exactly 0", targetMethod)
 	}
 
 	if targetSignature.Results().Len() != 1 {
-		return "", nil, fmt.Errorf("Expected exactly on return type on %+v", targetMethod)
+		return nil, nil, nil, fmt.Errorf("Expected exactly on return type on %+v", targetMethod)
 	}
 
 	targetNamedType, ok := targetSignature.Results().At(0).Type().(*types.Named)
 	if !ok {
-		return "", nil, fmt.Errorf("Return type of %+v is not a named type", targetSignature)
+		return nil, nil, nil, fmt.Errorf("Return type of %+v is not a named type", targetSignature)
 	}
 
 	targetInterface, ok := targetNamedType.Underlying().(*types.Interface)
 	if !ok {
-		return "", nil, fmt.Errorf("Return type of %+v is not an interface", targetSignature)
+		return nil, nil, nil, fmt.Errorf("Return type of %+v is not an interface", targetSignature)
 	}
 
 	targets := make([]*InjectionTarget, 0)
+	subcomponents := make([]*Subcomponent, 0)
 	for i := 0; i < targetInterface.NumMethods(); i++ {
 		method := targetInterface.Method(i)
 		if !method.Exported() {
@@ -308,67 +651,213 @@ func getTargetsFromInterface(
 		}
 
 		signature := method.Type().(*types.Signature)
-		if signature.Params().Len() > 0 {
-			return "", nil, fmt.Errorf("Expected method %+v in %+v to have no parameters",
-				method, targetInterface)
-		}
 
-		hasError := false
-		if signature.Results().Len() == 2 {
-			errType, ok := signature.Results().At(1).Type().(*types.Named)
-			if !ok {
-				return "", nil, fmt.Errorf("Expected %+v in %+v  to return an error",
+		// Generated components implement `Close() error` themselves
+		if method.Name() == closeFunc {
+			if signature.Params().Len() > 0 || signature.Results().Len() != 1 ||
+				!typeutil.IsError(signature.Results().At(0).Type()) {
+				return nil, nil, nil, fmt.Errorf("Expected %+v in %+v to be `Close() error`",
 					method, targetInterface)
 			}
 
-			if errType.Obj().Pkg() != nil {
-				return "", nil, fmt.Errorf("Expected %+v in %+v  to return an error",
-					method, targetInterface)
+			continue
+		}
+
+		// Generated components implement `Start(ctx) error` and `Stop(ctx) error` themselves
+		if method.Name() == startFunc || method.Name() == stopFunc {
+			if !typeutil.IsContextHook(signature) {
+				return nil, nil, nil, fmt.Errorf("Expected %+v in %+v to be `%s(context.Context) error`",
+					method, targetInterface, method.Name())
 			}
 
-			if errType.Obj().Name() != "error" {
-				return "", nil, fmt.Errorf("Expected %+v in %+v  to return an error",
+			continue
+		}
+
+		if signature.Results().Len() == 1 {
+			if resultName, ok := signature.Results().At(0).Type().(*types.Named); ok {
+				subcomponentResult := subcomponentResults[typeutil.IDFromNamed(resultName)]
+				if subcomponentResult != nil {
+					subcomponent, err := getSubcomponent(method, subcomponentResult)
+					if err != nil {
+						return nil, nil, nil, errors.Wrapf(err, "Error getting subcomponent for %+v", method)
+					}
+
+					subcomponents = append(subcomponents, subcomponent)
+					continue
+				}
+			}
+		}
+
+		// Methods that take a pointer to a struct and return nothing but an optional error
+		// inject the fields of the struct passed to them
+		if name := membersTarget(signature); name != nil {
+			targets = append(targets, &InjectionTarget{
+				MethodName: method.Name(),
+				Type:       signature.Params().At(0).Type(),
+				Name:       name,
+				IsPointer:  true,
+				HasError:   signature.Results().Len() == 1,
+				IsMembers:  true,
+			})
+			continue
+		}
+
+		// Target methods can take the context that is passed to providers and Init hooks,
+		// and parameters that are injected while the target is created
+		hasContext, err := validateTargetParams(signature)
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "Error getting parameters of %+v in %+v",
+				method, targetInterface)
+		}
+
+		// The value is optionally followed by a cleanup function and an error
+		results := signature.Results()
+		hasCleanup := false
+		if results.Len() > 1 {
+			isCleanup, cleanupHasError := cleanupSignature(results.At(1).Type())
+			hasCleanup = isCleanup && cleanupHasError
+		}
+
+		hasError := false
+		if (results.Len() == 2 && !hasCleanup) || results.Len() == 3 {
+			if !typeutil.IsError(results.At(results.Len() - 1).Type()) {
+				return nil, nil, nil, fmt.Errorf("Expected %+v in %+v  to return an error",
 					method, targetInterface)
 			}
 
 			hasError = true
 		}
 
-		// Expect either one result or two results, the second one being an error
-		if !(signature.Results().Len() == 1 || (signature.Results().Len() == 2 && hasError)) {
-			return "", nil, fmt.Errorf("Expected method %+v in %+v to have one result and optional error",
+		// Expect the value, an optional cleanup function and an optional error
+		if results.Len() == 0 || results.Len() > 3 || (results.Len() == 3 && !hasCleanup) {
+			return nil, nil, nil, fmt.Errorf(
+				"Expected method %+v in %+v to have one result, an optional `func() error` and an optional error",
 				method, targetInterface)
 		}
 
-		isPointer := false
-		realType := signature.Results().At(0).Type()
-		var namedType *types.Named
-		switch targetType := realType.(type) {
-		case *types.Named:
-			namedType = targetType
-		case *types.Pointer:
-			isPointer = true
-			namedType = targetType.Elem().(*types.Named)
-		default:
-			return "", nil, fmt.Errorf("Type %+v is not a valid target", targetType)
+		qualifier, err := methodQualifier(fileSet, targetNamedType, method.Name())
+		if err != nil {
+			return nil, nil, nil, err
 		}
 
+		// Types that are not named are provided by a provider method, or
+		// collected from multibindings
+		realType := signature.Results().At(0).Type()
+		namedType := namedFromType(realType)
+		_, isPointer := realType.(*types.Pointer)
+		isPointer = isPointer && namedType != nil
+
 		targets = append(targets, &InjectionTarget{
 			MethodName: method.Name(),
 			Type:       realType,
 			Name:       namedType,
+			Qualifier:  qualifier,
 			IsPointer:  isPointer,
 			HasError:   hasError,
+			HasCleanup: hasCleanup,
+			HasContext: hasContext,
+			Params:     tupleVars(signature.Params()),
 		})
 	}
 
-	return targetNamedType.Obj().Name(), targets, nil
+	return targetNamedType, targets, subcomponents, nil
 }
 
-func getNodesFromInterface(
-	interfaceType *types.Interface,
-	parent *resolutionNode,
-) ([]*resolutionNode, error) {
+// membersTarget returns the struct whose fields are injected by a method with the given
+// signature, or nil if the method is not a members injection method. Members injection
+// methods take a pointer to a struct and return either nothing or an error.
+func membersTarget(signature *types.Signature) *types.Named {
+	if signature.Params().Len() != 1 || signature.Results().Len() > 1 {
+		return nil
+	}
+
+	if signature.Results().Len() == 1 && !typeutil.IsError(signature.Results().At(0).Type()) {
+		return nil
+	}
+
+	pointerType, ok := signature.Params().At(0).Type().(*types.Pointer)
+	if !ok {
+		return nil
+	}
+
+	name, ok := pointerType.Elem().(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := name.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+
+	return name
+}
+
+// getSubcomponent validates the factory method of a subcomponent. Every parameter of
+// the method must be a provided module of the subcomponent, and every provided module
+// of the subcomponent must be a parameter.
+func getSubcomponent(
+	method *types.Func,
+	subcomponentResult *ResolveResult,
+) (*Subcomponent, error) {
+	providedModules := make(map[string]*structs.Struct)
+	for _, provider := range subcomponentResult.Providers {
+		moduleProvider, ok := provider.(*ModuleResolvedType)
+		if !ok {
+			continue
+		}
+
+		if moduleProvider.Module == nil ||
+			!typeutil.HasFieldOfType(moduleProvider.Module.Type, providedModuleType) {
+			continue
+		}
+
+		providedModules[typeutil.IDFromNamed(moduleProvider.Module.Name)] = moduleProvider.Module
+	}
+
+	signature := method.Type().(*types.Signature)
+	modules := make([]*structs.Struct, 0)
+	seenModules := make(map[string]struct{})
+	for i := 0; i < signature.Params().Len(); i++ {
+		param := signature.Params().At(i)
+		pointerParam, ok := param.Type().(*types.Pointer)
+		if !ok {
+			return nil, fmt.Errorf("Expected parameter %+v to be a pointer to a module", param)
+		}
+
+		moduleName, ok := pointerParam.Elem().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("Expected parameter %+v to be a pointer to a module", param)
+		}
+
+		moduleID := typeutil.IDFromNamed(moduleName)
+		module := providedModules[moduleID]
+		if module == nil {
+			return nil, fmt.Errorf("Parameter %+v is not a provided module of %+v",
+				param, subcomponentResult.TargetInterface)
+		}
+
+		if _, ok := seenModules[moduleID]; ok {
+			return nil, fmt.Errorf("Module %+v is a parameter twice", moduleID)
+		}
+		seenModules[moduleID] = struct{}{}
+
+		modules = append(modules, module)
+	}
+
+	for moduleID := range providedModules {
+		if _, ok := seenModules[moduleID]; !ok {
+			return nil, fmt.Errorf("Provided module %+v is not a parameter of %+v", moduleID, method)
+		}
+	}
+
+	return &Subcomponent{
+		MethodName: method.Name(),
+		Modules:    modules,
+		Result:     subcomponentResult,
+	}, nil
+}
+
+func getNodesFromInterface(interfaceType *types.Interface) ([]*resolutionNode, error) {
 	modulesMethod := typeutil.GetInterfaceMethod(interfaceType, modulesFunc)
 	if modulesMethod == nil {
 		return nil, nil
@@ -382,7 +871,6 @@ func getNodesFromInterface(
 	var nodes []*resolutionNode
 	for i := 0; i < modulesMethodSignature.Results().Len(); i++ {
 		nodes = append(nodes, &resolutionNode{
-			parent:   parent,
 			nodeType: modulesMethodSignature.Results().At(i).Type(),
 		})
 	}
@@ -391,9 +879,12 @@ func getNodesFromInterface(
 }
 
 func extractBindings(
+	fileSet *token.FileSet,
 	node *structs.Interface,
-) (map[string]*types.Named, error) {
+) (map[string]*types.Named, []*Multibinding, []*Injectable, error) {
 	bindings := make(map[string]*types.Named)
+	multibindings := make([]*Multibinding, 0)
+	injectables := make([]*Injectable, 0)
 	for i := 0; i < node.Type.NumMethods(); i++ {
 		method := node.Type.Method(i)
 		if !method.Exported() {
@@ -405,19 +896,76 @@ func extractBindings(
 		}
 
 		signature := method.Type().(*types.Signature)
-		if signature.Params().Len() != 1 && signature.Results().Len() != 1 {
-			return nil, fmt.Errorf("Expected method %+v in %+v to have one input and one output",
-				method, node.Type)
+		if signature.Results().Len() != 1 {
+			return nil, nil, nil, fmt.Errorf("Expected %s.%s to have one output", typeLabel(node.Name), method.Name())
+		}
+
+		// Methods returning a pointer declare the struct it points to injectable
+		_, isInjectable := signature.Results().At(0).Type().(*types.Pointer)
+		switch signature.Params().Len() {
+		case 0:
+			if !isInjectable {
+				return nil, nil, nil, fmt.Errorf("Expected %s.%s without inputs to return a pointer to a struct",
+					typeLabel(node.Name), method.Name())
+			}
+		case 1:
+		default:
+			return nil, nil, nil, fmt.Errorf("Expected %s.%s to have at most one input", typeLabel(node.Name), method.Name())
+		}
+
+		if isInjectable {
+			if err := validateInjectableDirectives(fileSet, node.Name, method.Name()); err != nil {
+				return nil, nil, nil, err
+			}
+
+			injectable, err := newInjectable(node.Name, method)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			injectables = append(injectables, injectable)
+			continue
 		}
 
 		interfaceName, ok := signature.Results().At(0).Type().(*types.Named)
 		if !ok {
-			return nil, fmt.Errorf("%+v was not named in %+v", signature.Params().At(0).Type(), node)
+			return nil, nil, nil, fmt.Errorf("%+v was not named in %+v", signature.Params().At(0).Type(), node)
+		}
+
+		isSingleton, err := methodIsSingleton(fileSet, node.Name, method.Name())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if isSingleton {
+			return nil, nil, nil, fmt.Errorf("Binding %s.%s cannot be marked as singleton, mark the bound struct instead",
+				typeLabel(node.Name), method.Name())
+		}
+
+		multibinding, err := newMultibinding(fileSet, node.Name, method.Name(), interfaceName)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		qualifier, err := methodQualifier(fileSet, node.Name, method.Name())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if multibinding != nil {
+			if qualifier != "" {
+				return nil, nil, nil, fmt.Errorf("Multibinding %s.%s cannot be qualified",
+					typeLabel(node.Name), method.Name())
+			}
+
+			multibinding.Binding = signature.Params().At(0).Type()
+			multibindings = append(multibindings, multibinding)
+			continue
 		}
 
-		interfaceID := typeutil.IDFromNamed(interfaceName)
+		interfaceID := typeutil.QualifiedID(interfaceName, qualifier)
 		if _, ok := bindings[interfaceID]; ok {
-			return nil, fmt.Errorf("Found duplicate binding for %+v in %+v", interfaceName, node)
+			return nil, nil, nil, fmt.Errorf("Found duplicate binding for %s in %+v", interfaceID, node)
 		}
 
 		var implementationName *types.Named
@@ -426,17 +974,17 @@ func extractBindings(
 		case *types.Pointer:
 			name, ok := actualType.Elem().(*types.Named)
 			if !ok {
-				return nil, fmt.Errorf("Expecting %+v to be a struct in %+v", implementationName, node)
+				return nil, nil, nil, fmt.Errorf("Expecting %+v to be a struct in %+v", implementationName, node)
 			}
 			implementationName = name
 		case *types.Named:
 			implementationName = actualType
 		default:
-			return nil, fmt.Errorf("%+v is not a pointer or a named type", implementationType)
+			return nil, nil, nil, fmt.Errorf("%+v is not a pointer or a named type", implementationType)
 		}
 
 		bindings[interfaceID] = implementationName
 	}
 
-	return bindings, nil
+	return bindings, multibindings, injectables, nil
 }
@@ -0,0 +1,105 @@
+package resolver
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/dimes/dihedral/typeutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// Packages are only loaded once, so all tests share the same file set
+var fileSet = token.NewFileSet()
+
+// resolve resolves the given definition from the given package in testdata
+func resolve(t *testing.T, packageName string, definitionName string) (*ResolveResult, error) {
+	definition, err := typeutil.FindInterface(fileSet, "github.com/dimes/dihedral/resolver/testdata/"+packageName, definitionName)
+	if !assert.NoError(t, err) || !assert.NotNil(t, definition) {
+		t.FailNow()
+	}
+
+	return ResolveComponentModules(fileSet, definition)
+}
+
+func TestScopes(t *testing.T) {
+	// A singleton of the parent outlives the scope of the subcomponent
+	_, err := resolve(t, "scopes", "SingletonDefinition")
+	assert.EqualError(t, errors.Cause(err), "scopes.Session (scope scopes.RequestScope) cannot be injected into "+
+		"scopes.Cache, which outlives it: scopes.Cache -> scopes.Session")
+
+	// Types scoped to the parent are created by the parent, even if requested by the subcomponent
+	_, err = resolve(t, "scopes", "ParentDefinition")
+	assert.EqualError(t, errors.Cause(err), "scopes.Session (scope scopes.RequestScope) cannot be injected into "+
+		"scopes.Registry, which outlives it: scopes.Registry -> scopes.Session")
+
+	_, err = resolve(t, "scopes", "UnscopedDefinition")
+	assert.EqualError(t, errors.Cause(err), "No component has scope scopes.RequestScope, which is required by "+
+		"scopes.Handler -> scopes.Session")
+}
+
+func TestCycles(t *testing.T) {
+	file, err := filepath.Abs(filepath.Join("testdata", "cycles", "cycles.go"))
+	assert.NoError(t, err)
+
+	_, err = resolve(t, "cycles", "CycleDefinition")
+	assert.EqualError(t, errors.Cause(err), "Dependency cycle: cycles.Client -> cycles.Server -> cycles.Client"+
+		"\n\tcycles.Client -> cycles.Server at "+file+":13:2"+
+		"\n\tcycles.Server -> cycles.Client at "+file+":20:2")
+
+	// Optional values are created with the type that depends on them
+	_, err = resolve(t, "cycles", "OptionalDefinition")
+	assert.EqualError(t, errors.Cause(err), "Dependency cycle: cycles.Publisher -> cycles.Subscriber -> cycles.Publisher"+
+		"\n\tcycles.Publisher -> cycles.Subscriber at "+file+":27:2"+
+		"\n\tcycles.Subscriber -> cycles.Publisher at "+file+":34:2")
+
+	// Providers create the type when they are called
+	_, err = resolve(t, "cycles", "ProviderDefinition")
+	assert.NoError(t, err)
+}
+
+func TestDuplicateMultibindingKeys(t *testing.T) {
+	file, err := filepath.Abs(filepath.Join("testdata", "multibindings", "multibindings.go"))
+	assert.NoError(t, err)
+
+	_, err = resolve(t, "multibindings", "DuplicateKeyDefinition")
+	assert.EqualError(t, errors.Cause(err), "Key \"deploy\" of map[string]multibindings.Command is contributed twice, "+
+		"at "+file+":13:1 and at "+file+":23:1")
+}
+
+func TestBindingMethodArity(t *testing.T) {
+	_, err := resolve(t, "bindings", "NoInputDefinition")
+	assert.EqualError(t, errors.Cause(err), "Expected bindings.NoInputModule.BindsStore without inputs to return a pointer to a struct")
+
+	_, err = resolve(t, "bindings", "TwoInputsDefinition")
+	assert.EqualError(t, errors.Cause(err), "Expected bindings.TwoInputsModule.BindsStore to have at most one input")
+}
+
+func TestMismatchingConstructor(t *testing.T) {
+	_, err := resolve(t, "constructors", "ConstructorsDefinition")
+	assert.EqualError(t, errors.Cause(err), "Invalid constructor NewPool of "+
+		"github.com/dimes/dihedral/resolver/testdata/constructors.Pool: "+
+		"Expected constructor NewPool to return *Pool, optionally followed by an error. "+
+		"Add the tag `di:\"constructor=-\"` to the marker to inject the fields instead")
+}
+
+func TestParamsInCachedTypes(t *testing.T) {
+	_, err := resolve(t, "params", "SingletonDefinition")
+	assert.EqualError(t, errors.Cause(err), "params.User is a parameter of the target method and cannot be injected "+
+		"into params.Profile, which is cached by the component: params.Profile -> params.User")
+
+	_, err = resolve(t, "params", "ScopedDefinition")
+	assert.EqualError(t, errors.Cause(err), "params.User is a parameter of the target method and cannot be injected "+
+		"into params.Session, which is cached by the component: params.Handler -> params.Session -> params.User")
+}
+
+func TestInvalidSingletonMethods(t *testing.T) {
+	_, err := resolve(t, "singletons", "ScopedDefinition")
+	assert.EqualError(t, errors.Cause(err), "singletons.ScopedModule.ProvidesConfig cannot be marked as singleton, "+
+		"since its module has scope singletons.RequestScope")
+
+	_, err = resolve(t, "singletons", "BindingDefinition")
+	assert.EqualError(t, errors.Cause(err), "Binding singletons.StoreModule.BindsStore cannot be marked as singleton, "+
+		"mark the bound struct instead")
+}
@@ -0,0 +1,181 @@
+package resolver
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/dimes/dihedral/typeutil"
+	"github.com/pkg/errors"
+)
+
+// scopeVisit is a type that has been visited with a given lifetime constraint
+type scopeVisit struct {
+	id         string
+	constraint int
+}
+
+// validateScopes checks that no type is injected into a type that outlives it, e.g.
+// that no request scoped type is injected into an application scoped type. The
+// components are the component being validated and all of its parents, starting
+// with the top-level component. Components earlier in the list outlive later ones.
+func validateScopes(components []*ResolveResult) error {
+	component := components[len(components)-1]
+	visited := make(map[scopeVisit]struct{})
+	for _, target := range component.Targets {
+		if err := validateScopesOf(
+			components,
+			target.Type,
+			target.Qualifier,
+			nil,
+			-1,
+			len(components)-1,
+			visited,
+		); err != nil {
+			return errors.Wrapf(err, "Error validating %s", target.MethodName)
+		}
+	}
+
+	for _, subcomponent := range component.Subcomponents {
+		subcomponents := append(append([]*ResolveResult{}, components...), subcomponent.Result)
+		if err := validateScopes(subcomponents); err != nil {
+			return errors.Wrapf(err, "Error validating subcomponent %s", subcomponent.MethodName)
+		}
+	}
+
+	return nil
+}
+
+// validateScopesOf validates the given type and its dependencies. The path contains
+// the types leading to this type, and owner is the index in the path of the type that
+// determined the constraint (or -1). Every type this type depends on has to be created
+// by the component at index constraint or one of its parents.
+func validateScopesOf(
+	components []*ResolveResult,
+	rawType types.Type,
+	qualifier string,
+	path []string,
+	owner int,
+	constraint int,
+	visited map[scopeVisit]struct{},
+) error {
+	// Types injected through a Lazy or Provider still have to live as long as
+	// the type they are injected into
+	rawType = typeutil.UnwrapAll(rawType)
+	node, err := newDependencyNode(components, rawType, qualifier)
+	if err != nil || node == nil {
+		return err
+	}
+
+	visit := scopeVisit{id: node.id, constraint: constraint}
+	if _, ok := visited[visit]; ok {
+		return nil
+	}
+	visited[visit] = struct{}{}
+
+	path = append(path, node.label)
+
+	scope := node.scope
+	depth := node.providerIndex
+	if depth < 0 && scope != nil {
+		depth = scopeIndex(components, scope)
+		if depth < 0 {
+			return fmt.Errorf("No component has scope %s, which is required by %s",
+				typeLabel(scope), strings.Join(path, " -> "))
+		}
+	}
+
+	if depth > constraint {
+		ownerLabel := "the component"
+		if owner >= 0 {
+			ownerLabel = path[owner]
+		}
+
+		description := node.typeName
+		if scope != nil {
+			description = description + " (scope " + typeLabel(scope) + ")"
+		}
+
+		return fmt.Errorf("%s cannot be injected into %s, which outlives it: %s",
+			description, ownerLabel, strings.Join(path[maxInt(owner, 0):], " -> "))
+	}
+
+	if depth >= 0 {
+		owner = len(path) - 1
+		constraint = depth
+	}
+
+	for _, dependency := range node.dependencies {
+		if err := validateScopesOf(
+			components,
+			dependency.rawType,
+			dependency.qualifier,
+			path,
+			owner,
+			constraint,
+			visited,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func lookupBinding(components []*ResolveResult, id string) *types.Named {
+	for _, component := range components {
+		if binding := component.Bindings[id]; binding != nil {
+			return binding
+		}
+	}
+
+	return nil
+}
+
+func lookupProvider(components []*ResolveResult, id string) (*ModuleResolvedType, int) {
+	for i, component := range components {
+		if provider, ok := component.Providers[id].(*ModuleResolvedType); ok {
+			return provider, i
+		}
+	}
+
+	return nil, -1
+}
+
+func scopeIndex(components []*ResolveResult, scope *types.Named) int {
+	for i, component := range components {
+		if component.Scope != nil && typeutil.IDFromNamed(component.Scope) == typeutil.IDFromNamed(scope) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// namedFromType returns the named type of a named type or a pointer to a named type
+func namedFromType(rawType types.Type) *types.Named {
+	switch typed := rawType.(type) {
+	case *types.Named:
+		return typed
+	case *types.Pointer:
+		named, _ := typed.Elem().(*types.Named)
+		return named
+	default:
+		return nil
+	}
+}
+
+// typeLabel returns a short, human readable name for the given type
+func typeLabel(rawType types.Type) string {
+	return types.TypeString(rawType, func(pkg *types.Package) string {
+		return pkg.Name()
+	})
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
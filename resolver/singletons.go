@@ -0,0 +1,46 @@
+package resolver
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"github.com/dimes/dihedral/typeutil"
+)
+
+const (
+	singletonDirective = "singleton"
+)
+
+// methodIsSingleton returns true if the given method is marked with a `//di:singleton`
+// directive, which caches the value it provides like the providers of a singleton module
+func methodIsSingleton(fileSet *token.FileSet, receiver *types.Named, methodName string) (bool, error) {
+	pkgs, err := typeutil.LoadPackages(fileSet, receiver.Obj().Pkg().Path())
+	if err != nil {
+		return false, err
+	}
+
+	isSingleton := false
+	for _, pkg := range pkgs {
+		for _, directive := range typeutil.Directives(typeutil.MethodDoc(pkg, receiver, methodName)) {
+			if directive.Name != singletonDirective {
+				continue
+			}
+
+			position := fileSet.Position(directive.Pos)
+			if isSingleton {
+				return false, fmt.Errorf("More than one //di:%s directive on %s.%s at %s",
+					singletonDirective, typeLabel(receiver), methodName, position)
+			}
+
+			if directive.Args != "" {
+				return false, fmt.Errorf("Unexpected arguments %s for //di:%s on %s.%s at %s",
+					directive.Args, singletonDirective, typeLabel(receiver), methodName, position)
+			}
+
+			isSingleton = true
+		}
+	}
+
+	return isSingleton, nil
+}
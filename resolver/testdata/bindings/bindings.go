@@ -0,0 +1,48 @@
+// Package bindings contains definitions with invalid binding modules
+package bindings
+
+import (
+	"github.com/dimes/dihedral/embeds"
+)
+
+// Store is bound by the binding modules
+type Store interface {
+	Name() string
+}
+
+// MemoryStore implements the store
+type MemoryStore struct {
+	inject embeds.Inject
+}
+
+// Name returns the name of the store
+func (m *MemoryStore) Name() string {
+	return "memory"
+}
+
+// NoInputModule has a method without inputs that does not return a pointer
+type NoInputModule interface {
+	BindsStore() Store
+}
+
+// NoInputDefinition includes the NoInputModule
+type NoInputDefinition interface {
+	Modules() NoInputModule
+	Target() BindingsComponent
+}
+
+// TwoInputsModule has a method with two inputs
+type TwoInputsModule interface {
+	BindsStore(impl *MemoryStore, other *MemoryStore) Store
+}
+
+// TwoInputsDefinition includes the TwoInputsModule
+type TwoInputsDefinition interface {
+	Modules() TwoInputsModule
+	Target() BindingsComponent
+}
+
+// BindingsComponent returns the store
+type BindingsComponent interface {
+	GetStore() Store
+}
@@ -0,0 +1,29 @@
+// Package constructors contains a definition whose struct has a constructor that
+// does not match
+package constructors
+
+import (
+	"github.com/dimes/dihedral/embeds"
+)
+
+// Pool is injectable, but NewPool does not return a pointer to it
+type Pool struct {
+	inject embeds.Inject
+
+	size int
+}
+
+// NewPool returns a pool of the given size
+func NewPool(size int) Pool {
+	return Pool{size: size}
+}
+
+// ConstructorsDefinition defines the ConstructorsComponent
+type ConstructorsDefinition interface {
+	Target() ConstructorsComponent
+}
+
+// ConstructorsComponent returns the pool
+type ConstructorsComponent interface {
+	GetPool() *Pool
+}
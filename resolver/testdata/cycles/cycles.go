@@ -0,0 +1,79 @@
+// Package cycles contains definitions whose types depend on themselves
+package cycles
+
+import (
+	"github.com/dimes/dihedral/embeds"
+	"github.com/dimes/dihedral/inject"
+)
+
+// Client depends on the server
+type Client struct {
+	inject embeds.Inject
+
+	Server *Server
+}
+
+// Server depends on the client
+type Server struct {
+	inject embeds.Inject
+
+	Client *Client
+}
+
+// Publisher optionally depends on the subscriber
+type Publisher struct {
+	inject embeds.Inject
+
+	Subscriber inject.Optional[*Subscriber]
+}
+
+// Subscriber depends on the publisher
+type Subscriber struct {
+	inject embeds.Inject
+
+	Publisher *Publisher
+}
+
+// Parent creates its children on demand
+type Parent struct {
+	inject embeds.Inject
+
+	Children inject.Provider[*Child]
+}
+
+// Child depends on the parent
+type Child struct {
+	inject embeds.Inject
+
+	Parent *Parent
+}
+
+// CycleDefinition requests the client, which depends on itself
+type CycleDefinition interface {
+	Target() CycleComponent
+}
+
+// CycleComponent returns the client
+type CycleComponent interface {
+	GetClient() *Client
+}
+
+// OptionalDefinition requests the publisher, which depends on itself through an Optional
+type OptionalDefinition interface {
+	Target() OptionalComponent
+}
+
+// OptionalComponent returns the publisher
+type OptionalComponent interface {
+	GetPublisher() *Publisher
+}
+
+// ProviderDefinition requests the parent, which depends on itself through a Provider
+type ProviderDefinition interface {
+	Target() ProviderComponent
+}
+
+// ProviderComponent returns the parent
+type ProviderComponent interface {
+	GetParent() (*Parent, error)
+}
@@ -0,0 +1,37 @@
+// Package multibindings contains a definition that contributes two commands with the
+// same key
+package multibindings
+
+// Command is contributed to the commands of the CLI
+type Command func() string
+
+// DeployModule contributes the deploy command
+type DeployModule struct{}
+
+// ProvidesDeploy provides the deploy command
+//
+//di:map "deploy"
+func (d *DeployModule) ProvidesDeploy() Command {
+	return func() string { return "deployed" }
+}
+
+// LegacyModule contributes another deploy command
+type LegacyModule struct{}
+
+// ProvidesLegacyDeploy provides the legacy deploy command
+//
+//di:map "deploy"
+func (l *LegacyModule) ProvidesLegacyDeploy() Command {
+	return func() string { return "deployed the old way" }
+}
+
+// DuplicateKeyDefinition contributes both deploy commands
+type DuplicateKeyDefinition interface {
+	Modules() (*DeployModule, *LegacyModule)
+	Target() DuplicateKeyComponent
+}
+
+// DuplicateKeyComponent returns the commands
+type DuplicateKeyComponent interface {
+	GetCommands() map[string]Command
+}
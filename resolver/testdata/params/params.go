@@ -0,0 +1,59 @@
+// Package params contains definitions that inject parameters of target methods into
+// types that are cached by the component
+package params
+
+import (
+	"github.com/dimes/dihedral/embeds"
+)
+
+// User is a parameter of the target methods
+type User string
+
+// Profile is a singleton that depends on the user
+type Profile struct {
+	inject    embeds.Inject
+	singleton embeds.Singleton
+
+	User User
+}
+
+// RequestScope is the scope of the ScopedDefinition
+type RequestScope struct {
+	scope embeds.Scope
+}
+
+// Session is scoped and depends on the user
+type Session struct {
+	inject embeds.Inject
+	scope  RequestScope
+
+	User User
+}
+
+// Handler depends on the session
+type Handler struct {
+	inject embeds.Inject
+
+	Session *Session
+}
+
+// SingletonDefinition injects the user into a singleton
+type SingletonDefinition interface {
+	Target() SingletonComponent
+}
+
+// SingletonComponent returns the profile of a user
+type SingletonComponent interface {
+	GetProfile(user User) *Profile
+}
+
+// ScopedDefinition injects the user into a scoped type
+type ScopedDefinition interface {
+	Scope() RequestScope
+	Target() ScopedComponent
+}
+
+// ScopedComponent returns the handler of a user
+type ScopedComponent interface {
+	GetHandler(user User) *Handler
+}
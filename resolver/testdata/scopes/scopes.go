@@ -0,0 +1,110 @@
+// Package scopes contains definitions that inject short-lived types into types that
+// outlive them
+package scopes
+
+import (
+	"github.com/dimes/dihedral/embeds"
+)
+
+// ApplicationScope is the scope of the parent components
+type ApplicationScope struct {
+	scope embeds.Scope
+}
+
+// RequestScope is the scope of the subcomponents
+type RequestScope struct {
+	scope embeds.Scope
+}
+
+// Session is created once per request
+type Session struct {
+	inject embeds.Inject
+	scope  RequestScope
+}
+
+// Cache is a singleton of the parent component
+type Cache struct {
+	Session *Session
+}
+
+// CacheModule provides the cache
+type CacheModule struct {
+	singleton embeds.Singleton
+}
+
+// ProvidesCache provides the cache from the session of a request
+func (c *CacheModule) ProvidesCache(session *Session) *Cache {
+	return &Cache{Session: session}
+}
+
+// Registry is created once per parent component
+type Registry struct {
+	inject embeds.Inject
+	scope  ApplicationScope
+
+	Session *Session
+}
+
+// Handler is not cached
+type Handler struct {
+	inject embeds.Inject
+
+	Session *Session
+}
+
+// SingletonDefinition injects the session into a singleton of the parent
+type SingletonDefinition interface {
+	Modules() (*CacheModule, SingletonRequestDefinition)
+	Scope() ApplicationScope
+	Target() SingletonComponent
+}
+
+// SingletonComponent creates request components
+type SingletonComponent interface {
+	NewRequestComponent() SingletonRequestComponent
+}
+
+// SingletonRequestDefinition requests the cache of the parent
+type SingletonRequestDefinition interface {
+	Scope() RequestScope
+	Target() SingletonRequestComponent
+}
+
+// SingletonRequestComponent returns the cache
+type SingletonRequestComponent interface {
+	GetCache() *Cache
+}
+
+// ParentDefinition injects the session into a type created by the parent
+type ParentDefinition interface {
+	Modules() ParentRequestDefinition
+	Scope() ApplicationScope
+	Target() ParentComponent
+}
+
+// ParentComponent creates request components
+type ParentComponent interface {
+	NewRequestComponent() ParentRequestComponent
+}
+
+// ParentRequestDefinition requests the registry of the parent
+type ParentRequestDefinition interface {
+	Scope() RequestScope
+	Target() ParentRequestComponent
+}
+
+// ParentRequestComponent returns the registry
+type ParentRequestComponent interface {
+	GetRegistry() *Registry
+}
+
+// UnscopedDefinition requests the session from a component without a request scope
+type UnscopedDefinition interface {
+	Scope() ApplicationScope
+	Target() UnscopedComponent
+}
+
+// UnscopedComponent returns the handler
+type UnscopedComponent interface {
+	GetHandler() *Handler
+}
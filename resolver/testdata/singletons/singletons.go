@@ -0,0 +1,65 @@
+// Package singletons contains definitions with methods that cannot be marked as singletons
+package singletons
+
+import (
+	"github.com/dimes/dihedral/embeds"
+)
+
+// RequestScope is the scope of the ScopedDefinition
+type RequestScope struct {
+	scope embeds.Scope
+}
+
+// Config is provided by the modules
+type Config struct{}
+
+// ScopedModule is scoped, so its methods are already cached
+type ScopedModule struct {
+	scope RequestScope
+}
+
+// ProvidesConfig provides the configuration
+//
+//di:singleton
+func (s *ScopedModule) ProvidesConfig() *Config {
+	return &Config{}
+}
+
+// ScopedDefinition includes the ScopedModule
+type ScopedDefinition interface {
+	Modules() *ScopedModule
+	Scope() RequestScope
+	Target() SingletonsComponent
+}
+
+// Store is bound to MemoryStore
+type Store interface {
+	Name() string
+}
+
+// MemoryStore implements the store
+type MemoryStore struct {
+	inject embeds.Inject
+}
+
+// Name returns the name of the store
+func (m *MemoryStore) Name() string {
+	return "memory"
+}
+
+// StoreModule marks a binding as singleton
+type StoreModule interface {
+	//di:singleton
+	BindsStore(impl *MemoryStore) Store
+}
+
+// BindingDefinition includes the StoreModule
+type BindingDefinition interface {
+	Modules() StoreModule
+	Target() SingletonsComponent
+}
+
+// SingletonsComponent returns the configuration
+type SingletonsComponent interface {
+	GetConfig() *Config
+}
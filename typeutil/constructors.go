@@ -0,0 +1,108 @@
+package typeutil
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+const (
+	constructorTag    = "constructor="
+	constructorPrefix = "New"
+)
+
+// Constructor returns the package-level function that creates the given struct, which is
+// marked with a field of type markerType, or nil if the struct is created by assigning its
+// fields. The function is either named by a `di:"constructor=NewName"` tag on the marker
+// field, or is named New followed by the name of the struct. It has to return a pointer
+// to the struct, optionally followed by an error. The tag `di:"constructor=-"` skips the
+// function named after the struct.
+func Constructor(name *types.Named, targetStruct *types.Struct, markerType reflect.Type) (*types.Func, error) {
+	functionName := ""
+	for i := 0; i < targetStruct.NumFields(); i++ {
+		if !isFieldOfType(targetStruct.Field(i), markerType) {
+			continue
+		}
+
+		for _, option := range strings.Split(reflect.StructTag(targetStruct.Tag(i)).Get(diTag), ",") {
+			if option == "" {
+				continue
+			}
+
+			if !strings.HasPrefix(option, constructorTag) || functionName != "" {
+				return nil, fmt.Errorf("Unknown option %q in tag of field %s", option, targetStruct.Field(i).Name())
+			}
+
+			functionName = strings.TrimPrefix(option, constructorTag)
+		}
+	}
+
+	if functionName == skipTag {
+		return nil, nil
+	}
+
+	if functionName != "" {
+		return constructorFunc(name, functionName)
+	}
+
+	// Constructors by convention have to match, so that a mistake in their signature
+	// does not silently inject the fields instead
+	functionName = constructorPrefix + name.Obj().Name()
+	if name.Obj().Pkg().Scope().Lookup(functionName) == nil {
+		return nil, nil
+	}
+
+	function, err := constructorFunc(name, functionName)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid constructor %s of %s: %s. Add the tag `di:\"constructor=-\"` "+
+			"to the marker to inject the fields instead", functionName, IDFromNamed(name), err)
+	}
+
+	return function, nil
+}
+
+// constructorFunc returns the function with the given name in the package of the given
+// struct if it is a valid constructor of the struct
+func constructorFunc(name *types.Named, functionName string) (*types.Func, error) {
+	if name.TypeArgs().Len() > 0 {
+		return nil, fmt.Errorf("Generic struct %s cannot have a constructor", IDFromNamed(name))
+	}
+
+	function, ok := name.Obj().Pkg().Scope().Lookup(functionName).(*types.Func)
+	if !ok || !function.Exported() {
+		return nil, fmt.Errorf("Constructor %s of %s is not an exported function", functionName, IDFromNamed(name))
+	}
+
+	signature := function.Type().(*types.Signature)
+	if signature.TypeParams().Len() > 0 || signature.Variadic() {
+		return nil, fmt.Errorf("Constructor %s of %s cannot be generic or variadic", functionName, IDFromNamed(name))
+	}
+
+	results := signature.Results()
+	if results.Len() == 0 || results.Len() > 2 ||
+		!types.Identical(results.At(0).Type(), types.NewPointer(name)) ||
+		(results.Len() == 2 && !IsError(results.At(1).Type())) {
+		return nil, fmt.Errorf("Expected constructor %s to return *%s, optionally followed by an error",
+			functionName, name.Obj().Name())
+	}
+
+	return function, nil
+}
+
+// ConstructorParams returns the parameters of the given constructor as unqualified
+// injected fields
+func ConstructorParams(constructor *types.Func) []*InjectedField {
+	params := constructor.Type().(*types.Signature).Params()
+	fields := make([]*InjectedField, params.Len())
+	for i := 0; i < params.Len(); i++ {
+		fields[i] = &InjectedField{Var: params.At(i)}
+	}
+
+	return fields
+}
+
+// IsError returns true if the given type is the predeclared error type
+func IsError(rawType types.Type) bool {
+	return types.Identical(rawType, types.Universe.Lookup("error").Type())
+}
@@ -0,0 +1,128 @@
+package typeutil
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	directivePrefix = "//di:"
+)
+
+// Directive is a comment of the form `//di:name args` in the doc comment
+// of a declaration
+type Directive struct {
+	Name string
+	Args string
+	Pos  token.Pos
+}
+
+// Directives returns the directives in the given doc comment
+func Directives(doc *ast.CommentGroup) []*Directive {
+	if doc == nil {
+		return nil
+	}
+
+	directives := make([]*Directive, 0)
+	for _, comment := range doc.List {
+		if !strings.HasPrefix(comment.Text, directivePrefix) {
+			continue
+		}
+
+		text := strings.TrimPrefix(comment.Text, directivePrefix)
+		name, args := text, ""
+		if index := strings.IndexAny(text, " \t"); index >= 0 {
+			name, args = text[:index], strings.TrimSpace(text[index:])
+		}
+
+		directives = append(directives, &Directive{
+			Name: name,
+			Args: args,
+			Pos:  comment.Pos(),
+		})
+	}
+
+	return directives
+}
+
+// MethodDoc returns the doc comment of the given method of the named type. The
+// method is either declared on a struct or is part of an interface declaration.
+// Returns nil if the method has no doc comment or cannot be found in the package.
+func MethodDoc(pkg *packages.Package, receiver *types.Named, methodName string) *ast.CommentGroup {
+	receiverName := receiver.Obj().Name()
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			switch typedDecl := decl.(type) {
+			case *ast.FuncDecl:
+				if typedDecl.Recv == nil || len(typedDecl.Recv.List) != 1 ||
+					typedDecl.Name.Name != methodName {
+					continue
+				}
+
+				receiverType := typedDecl.Recv.List[0].Type
+				if star, ok := receiverType.(*ast.StarExpr); ok {
+					receiverType = star.X
+				}
+
+				if ident, ok := receiverType.(*ast.Ident); ok && ident.Name == receiverName {
+					return typedDecl.Doc
+				}
+			case *ast.GenDecl:
+				for _, spec := range typedDecl.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok || typeSpec.Name.Name != receiverName {
+						continue
+					}
+
+					interfaceType, ok := typeSpec.Type.(*ast.InterfaceType)
+					if !ok {
+						continue
+					}
+
+					for _, method := range interfaceType.Methods.List {
+						for _, name := range method.Names {
+							if name.Name == methodName {
+								return method.Doc
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// TypeDoc returns the doc comment of the declaration of the given named type. Returns
+// nil if the type has no doc comment or cannot be found in the package.
+func TypeDoc(pkg *packages.Package, name *types.Named) *ast.CommentGroup {
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if typeSpec.Name.Name != name.Obj().Name() {
+					continue
+				}
+
+				// The doc comment of a type declared on its own is attached to the declaration
+				if typeSpec.Doc == nil && len(genDecl.Specs) == 1 {
+					return genDecl.Doc
+				}
+
+				return typeSpec.Doc
+			}
+		}
+	}
+
+	return nil
+}
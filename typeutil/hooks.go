@@ -0,0 +1,82 @@
+package typeutil
+
+import (
+	"go/types"
+)
+
+const (
+	// InitHook is the method that is called after an injected struct is created
+	InitHook = "Init"
+
+	// CloseHook is the method that is called when the component that created an
+	// injected struct is closed
+	CloseHook = "Close"
+
+	// StartHook and StopHook are the methods that are called when the component that
+	// created a value is started and stopped
+	StartHook = "Start"
+	StopHook  = "Stop"
+)
+
+// HasHook returns true if a pointer to the given type has an exported method with the
+// given name and the signature `func() error`. Promoted methods are included.
+func HasHook(name *types.Named, hookName string) bool {
+	signature := lookupHook(types.NewPointer(name), hookName)
+	return signature != nil && signature.Params().Len() == 0 && signature.Results().Len() == 1 &&
+		IsError(signature.Results().At(0).Type())
+}
+
+// HasCloser returns true if the given type has the exported method Close with the
+// signature `func() error`, which makes it an io.Closer. Promoted methods are included.
+func HasCloser(rawType types.Type) bool {
+	signature := lookupHook(rawType, CloseHook)
+	return signature != nil && signature.Params().Len() == 0 && signature.Results().Len() == 1 &&
+		IsError(signature.Results().At(0).Type())
+}
+
+// HasContextHook returns true if a pointer to the given type has an exported method with
+// the given name and the signature `func(context.Context) error`. Promoted methods are
+// included.
+func HasContextHook(name *types.Named, hookName string) bool {
+	signature := lookupHook(types.NewPointer(name), hookName)
+	return signature != nil && IsContextHook(signature)
+}
+
+// HasLifecycle returns true if the given type has the exported methods Start and Stop with
+// the signature `func(context.Context) error`, which makes it an inject.Lifecycle.
+// Promoted methods are included.
+func HasLifecycle(rawType types.Type) bool {
+	for _, hookName := range []string{StartHook, StopHook} {
+		signature := lookupHook(rawType, hookName)
+		if signature == nil || !IsContextHook(signature) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsContextHook returns true if the given signature is `func(context.Context) error`
+func IsContextHook(signature *types.Signature) bool {
+	return signature.Params().Len() == 1 && IsContext(signature.Params().At(0).Type()) &&
+		signature.Results().Len() == 1 && IsError(signature.Results().At(0).Type())
+}
+
+// IsContext returns true if the given type is context.Context
+func IsContext(rawType types.Type) bool {
+	name, ok := rawType.(*types.Named)
+	return ok && name.Obj().Pkg() != nil && name.Obj().Pkg().Path() == "context" &&
+		name.Obj().Name() == "Context"
+}
+
+// lookupHook returns the signature of the exported method of the given type with the
+// given name, or nil if there is no such method
+func lookupHook(rawType types.Type, hookName string) *types.Signature {
+	object, _, _ := types.LookupFieldOrMethod(rawType, true, nil, hookName)
+	method, ok := object.(*types.Func)
+	if !ok || !method.Exported() {
+		return nil
+	}
+
+	return method.Type().(*types.Signature)
+}
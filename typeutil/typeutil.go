@@ -6,15 +6,153 @@ import (
 	"go/token"
 	"go/types"
 	"reflect"
+	"strings"
 
+	"github.com/dimes/dihedral/inject"
 	"github.com/dimes/dihedral/structs"
 	"github.com/pkg/errors"
 	"golang.org/x/tools/go/packages"
 )
 
-// IDFromNamed returns a unique string for the given name
+const (
+	diTag        = "di"
+	skipTag      = "-"
+	qualifierTag = "name="
+	assistedTag  = "assisted"
+
+	lazyName     = "Lazy"
+	providerName = "Provider"
+	optionalName = "Optional"
+)
+
+var (
+	injectPackagePath = reflect.TypeOf(inject.Provider[struct{}](nil)).PkgPath()
+
+	// Packages are loaded once per run of the generator
+	loadedPackages = make(map[string][]*packages.Package)
+)
+
+// IDFromNamed returns a unique string for the given name. Instantiated generic
+// types include their type arguments.
 func IDFromNamed(name *types.Named) string {
-	return name.Obj().Pkg().Path() + "." + name.Obj().Name()
+	// Predeclared types like error have no package
+	if name.Obj().Pkg() == nil {
+		return name.Obj().Name()
+	}
+
+	id := name.Obj().Pkg().Path() + "." + name.Obj().Name()
+	if name.TypeArgs().Len() == 0 {
+		return id
+	}
+
+	typeArgs := make([]string, 0, name.TypeArgs().Len())
+	for i := 0; i < name.TypeArgs().Len(); i++ {
+		typeArgs = append(typeArgs, types.TypeString(name.TypeArgs().At(i), nil))
+	}
+
+	return id + "[" + strings.Join(typeArgs, ",") + "]"
+}
+
+// TypeID returns a unique string for any type. Named types and pointers to named
+// types have the ID returned by IDFromNamed, since they are provided by the same
+// provider. All other types are identified by their full type string.
+func TypeID(rawType types.Type) string {
+	switch typed := rawType.(type) {
+	case *types.Named:
+		return IDFromNamed(typed)
+	case *types.Pointer:
+		if named, ok := typed.Elem().(*types.Named); ok {
+			return IDFromNamed(named)
+		}
+	}
+
+	return types.TypeString(rawType, nil)
+}
+
+// QualifiedID returns a unique string for the given type with the given qualifier. Types
+// without a qualifier have the same ID as returned by TypeID.
+func QualifiedID(rawType types.Type, qualifier string) string {
+	if qualifier == "" {
+		return TypeID(rawType)
+	}
+
+	return TypeID(rawType) + "(" + qualifierTag + qualifier + ")"
+}
+
+// IsQualifier returns true if the given string can be used as a qualifier
+func IsQualifier(qualifier string) bool {
+	return token.IsIdentifier(qualifier)
+}
+
+// IDFromType returns a unique string for named types, pointers to named types
+// and slices of those, as well as for maps from basic or named types to those.
+// Returns false for all other types.
+func IDFromType(rawType types.Type) (string, bool) {
+	switch typed := rawType.(type) {
+	case *types.Named:
+		return IDFromNamed(typed), true
+	case *types.Pointer:
+		named, ok := typed.Elem().(*types.Named)
+		if !ok {
+			return "", false
+		}
+		return "*" + IDFromNamed(named), true
+	case *types.Slice:
+		elemID, ok := elementID(typed.Elem())
+		if !ok {
+			return "", false
+		}
+		return "[]" + elemID, true
+	case *types.Map:
+		var keyID string
+		switch key := typed.Key().(type) {
+		case *types.Basic:
+			keyID = key.Name()
+		case *types.Named:
+			keyID = IDFromNamed(key)
+		default:
+			return "", false
+		}
+
+		elemID, ok := elementID(typed.Elem())
+		if !ok {
+			return "", false
+		}
+		return "map[" + keyID + "]" + elemID, true
+	default:
+		return "", false
+	}
+}
+
+// elementID returns the ID of the element of a slice or map, which has to be a named
+// type or a pointer to a named type
+func elementID(elem types.Type) (string, bool) {
+	switch elem.(type) {
+	case *types.Named, *types.Pointer:
+		return IDFromType(elem)
+	default:
+		return "", false
+	}
+}
+
+// LoadPackages loads the syntax and types of the given package. Packages are
+// only loaded once, so positions in the package are the same across calls.
+func LoadPackages(fileSet *token.FileSet, packageName string) ([]*packages.Package, error) {
+	if pkgs, ok := loadedPackages[packageName]; ok {
+		return pkgs, nil
+	}
+
+	config := &packages.Config{
+		Mode: packages.LoadSyntax,
+		Fset: fileSet,
+	}
+	pkgs, err := packages.Load(config, packageName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error loading package %s", packageName)
+	}
+
+	loadedPackages[packageName] = pkgs
+	return pkgs, nil
 }
 
 // FindInterface finds the given interface name in the given package. Returns
@@ -24,12 +162,9 @@ func FindInterface(
 	packageName string,
 	interfaceName string,
 ) (*structs.Interface, error) {
-	config := &packages.Config{
-		Mode: packages.LoadSyntax,
-	}
-	pkgs, err := packages.Load(config, packageName)
+	pkgs, err := LoadPackages(fileSet, packageName)
 	if err != nil {
-		return nil, errors.Wrapf(err, "Error loading package %s", packageName)
+		return nil, err
 	}
 
 	for _, astPkg := range pkgs {
@@ -84,6 +219,37 @@ func HasFieldOfType(
 	targetStruct *types.Struct,
 	fieldType reflect.Type,
 ) bool {
+	for i := 0; i < targetStruct.NumFields(); i++ {
+		if isFieldOfType(targetStruct.Field(i), fieldType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isFieldOfType returns true if the given field is non-exported and of type fieldType
+func isFieldOfType(field *types.Var, fieldType reflect.Type) bool {
+	if field.Exported() {
+		return false
+	}
+
+	namedType, ok := field.Type().(*types.Named)
+	if !ok || namedType.Obj().Pkg() == nil {
+		return false
+	}
+
+	return fieldType.PkgPath() == namedType.Obj().Pkg().Path() &&
+		fieldType.Name() == namedType.Obj().Name()
+}
+
+// GetMarkedFieldType returns the type of the first non-exported field of the given
+// struct whose type is itself a struct with a non-exported field of type markerType,
+// or nil if there is no such field
+func GetMarkedFieldType(
+	targetStruct *types.Struct,
+	markerType reflect.Type,
+) *types.Named {
 	for i := 0; i < targetStruct.NumFields(); i++ {
 		field := targetStruct.Field(i)
 		if field.Exported() {
@@ -95,16 +261,161 @@ func HasFieldOfType(
 			continue
 		}
 
-		if fieldType.PkgPath() != namedType.Obj().Pkg().Path() {
+		fieldStruct, ok := namedType.Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+
+		if HasFieldOfType(fieldStruct, markerType) {
+			return namedType
+		}
+	}
+
+	return nil
+}
+
+// InjectedField is an injected field of a struct
+type InjectedField struct {
+	*types.Var
+	Qualifier  string // Qualifier from the `di:"name=..."` tag, or empty
+	IsAssisted bool   // True if the field has the `di:"assisted"` tag
+}
+
+// InjectedFields returns the exported fields of the given struct that are not
+// skipped with the `di:"-"` tag. Fields with the `di:"assisted"` tag are not injected,
+// but passed to the assisted factory of the struct, see AssistedStruct.
+func InjectedFields(targetStruct *types.Struct) ([]*InjectedField, error) {
+	fields := make([]*InjectedField, 0)
+	for i := 0; i < targetStruct.NumFields(); i++ {
+		field := targetStruct.Field(i)
+		if !field.Exported() {
 			continue
 		}
 
-		if fieldType.Name() != namedType.Obj().Name() {
+		options := strings.Split(reflect.StructTag(targetStruct.Tag(i)).Get(diTag), ",")
+		if options[0] == skipTag {
 			continue
 		}
 
-		return true
+		qualifier := ""
+		isAssisted := false
+		for _, option := range options {
+			if option == "" {
+				continue
+			}
+
+			if option == assistedTag {
+				isAssisted = true
+				continue
+			}
+
+			if !strings.HasPrefix(option, qualifierTag) {
+				return nil, fmt.Errorf("Unknown option %q in tag of field %s", option, field.Name())
+			}
+
+			if qualifier != "" {
+				return nil, fmt.Errorf("Field %s has more than one qualifier", field.Name())
+			}
+
+			qualifier = strings.TrimPrefix(option, qualifierTag)
+			if !IsQualifier(qualifier) {
+				return nil, fmt.Errorf("Qualifier %q of field %s is not a valid identifier", qualifier, field.Name())
+			}
+		}
+
+		if isAssisted && qualifier != "" {
+			return nil, fmt.Errorf("Assisted field %s cannot be qualified", field.Name())
+		}
+
+		fields = append(fields, &InjectedField{
+			Var:        field,
+			Qualifier:  qualifier,
+			IsAssisted: isAssisted,
+		})
 	}
 
-	return false
+	return fields, nil
+}
+
+// AssistedStruct returns the struct created by the given assisted factory, or nil if the
+// type is not an assisted factory. An assisted factory of a struct has the form
+// `func(...) (*Struct, error)`, and takes the assisted fields of the struct in the order
+// in which they are declared.
+func AssistedStruct(rawType types.Type) *types.Named {
+	signature, ok := rawType.(*types.Signature)
+	if !ok || signature.Variadic() || signature.Results().Len() != 2 ||
+		!IsError(signature.Results().At(1).Type()) {
+		return nil
+	}
+
+	pointer, ok := signature.Results().At(0).Type().(*types.Pointer)
+	if !ok {
+		return nil
+	}
+
+	name, ok := pointer.Elem().(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := name.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+
+	return name
+}
+
+// AssistedFields returns the assisted fields of the given injected fields
+func AssistedFields(fields []*InjectedField) []*InjectedField {
+	assisted := make([]*InjectedField, 0)
+	for _, field := range fields {
+		if field.IsAssisted {
+			assisted = append(assisted, field)
+		}
+	}
+
+	return assisted
+}
+
+// UnwrapType returns the type wrapped by an inject.Lazy, inject.Provider or inject.Optional
+// together with the wrapper. If the given type is not wrapped, it is returned as is and
+// the wrapper is nil.
+func UnwrapType(rawType types.Type) (types.Type, *types.Named) {
+	named, ok := rawType.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != injectPackagePath {
+		return rawType, nil
+	}
+
+	switch named.Obj().Name() {
+	case lazyName, providerName, optionalName:
+	default:
+		return rawType, nil
+	}
+
+	if named.TypeArgs().Len() != 1 {
+		return rawType, nil
+	}
+
+	return named.TypeArgs().At(0), named
+}
+
+// UnwrapAll removes all wrappers from the given type
+func UnwrapAll(rawType types.Type) types.Type {
+	for {
+		unwrapped, wrapper := UnwrapType(rawType)
+		if wrapper == nil {
+			return rawType
+		}
+		rawType = unwrapped
+	}
+}
+
+// IsLazy returns true if the given wrapper returned by UnwrapType is an inject.Lazy
+func IsLazy(wrapper *types.Named) bool {
+	return wrapper != nil && wrapper.Obj().Name() == lazyName
+}
+
+// IsOptional returns true if the given wrapper returned by UnwrapType is an inject.Optional
+func IsOptional(wrapper *types.Named) bool {
+	return wrapper != nil && wrapper.Obj().Name() == optionalName
 }